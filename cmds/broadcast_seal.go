@@ -0,0 +1,74 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+// BroadcastSealCommand is the last step of the offline sign-seal workflow:
+// it refuses to emit the seal at all unless the selected operation's
+// FactSigns already carry at least Threshold weight of the account's Keys,
+// so a seal can never be pushed to a node prematurely. Once that check
+// passes, it pushes the seal to a node over SendSealFlags the same way
+// create-account/transfer do; with no --remote given it falls back to
+// printing the seal to stdout.
+type BroadcastSealCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `name:"privatekey" help:"privatekey to authenticate the --remote connection" optional:""`
+	Seal       FileLoad       `arg:"" name:"seal" help:"seal to broadcast, read from stdin if \"-\"" required:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Key        []KeyFlag      `name:"key" help:"account's registered key (ex: \"<public key>,<weight>\")" sep:"@" required:""` // nolint
+	Threshold  uint           `name:"threshold" help:"account's registered threshold" required:""`
+	Operation  int            `name:"operation" help:"index of the operation whose signs must meet threshold" default:"0"`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	SendSealFlags
+}
+
+func NewBroadcastSealCommand() BroadcastSealCommand {
+	return BroadcastSealCommand{
+		BaseCommand: NewBaseCommand("broadcast-seal"),
+	}
+}
+
+func (cmd *BroadcastSealCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	keys, err := parseKeysAndThreshold(cmd.Key, cmd.Threshold)
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSeal(cmd.Seal.Bytes(), cmd.NetworkID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	so, ok := sl.(operation.Seal)
+	if !ok {
+		return xerrors.Errorf("seal is not operation.Seal, %T", sl)
+	}
+
+	op, err := operationByIndex(so, cmd.Operation)
+	if err != nil {
+		return err
+	}
+
+	weight, _ := factSignWeight(op.Fact(), op.Signs(), keys, cmd.NetworkID.Bytes())
+	if weight < keys.Threshold() {
+		return xerrors.Errorf(
+			"insufficient signs for broadcast: weight %d of threshold %d", weight, keys.Threshold(),
+		)
+	}
+
+	if cmd.SendSealFlags.requested() {
+		return cmd.SendSealFlags.send(cmd.Privatekey, so)
+	}
+
+	cmd.pretty(cmd.Pretty, so)
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/localtime"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/currency"
+)
+
+type NewCoinCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"owner's privatekey" required:""`
+	Owner      AddressFlag    `arg:"" name:"owner" help:"owner address" required:""`
+	CoinID     string         `arg:"" name:"coin-id" help:"new coin id" required:""`
+	Name       string         `arg:"" name:"name" help:"coin display name" required:""`
+	Token      string         `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	Memo       string         `name:"memo" help:"memo"`
+	Seal       FileLoad       `help:"seal" optional:""`
+	owner      base.Address
+}
+
+func NewNewCoinCommand() NewCoinCommand {
+	return NewCoinCommand{
+		BaseCommand: NewBaseCommand("new-coin-operation"),
+	}
+}
+
+func (cmd *NewCoinCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if a, err := cmd.Owner.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid owner format, %q: %w", cmd.Owner.String(), err)
+	} else {
+		cmd.owner = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	fact := currency.NewNewCoinFact([]byte(cmd.Token), cmd.owner, currency.CoinID(cmd.CoinID), cmd.Name)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := currency.NewNewCoinOperation(fact, fs, cmd.Memo)
+	if err != nil {
+		return xerrors.Errorf("failed to create new-coin operation: %w", err)
+	}
+
+	sl, err := loadSealAndAddOperation(cmd.Seal.Bytes(), cmd.Privatekey, cmd.NetworkID.Bytes(), op)
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
+
+type ChangeCoinOwnerCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"sender's privatekey" required:""`
+	Sender     AddressFlag    `arg:"" name:"sender" help:"current coin owner" required:""`
+	CoinID     string         `arg:"" name:"coin-id" help:"coin id" required:""`
+	Receiver   AddressFlag    `arg:"" name:"receiver" help:"new coin owner" required:""`
+	Token      string         `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	Memo       string         `name:"memo" help:"memo"`
+	Seal       FileLoad       `help:"seal" optional:""`
+	sender     base.Address
+	receiver   base.Address
+}
+
+func NewChangeCoinOwnerCommand() ChangeCoinOwnerCommand {
+	return ChangeCoinOwnerCommand{
+		BaseCommand: NewBaseCommand("change-coin-owner-operation"),
+	}
+}
+
+func (cmd *ChangeCoinOwnerCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if a, err := cmd.Sender.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid sender format, %q: %w", cmd.Sender.String(), err)
+	} else {
+		cmd.sender = a
+	}
+
+	if a, err := cmd.Receiver.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid receiver format, %q: %w", cmd.Receiver.String(), err)
+	} else {
+		cmd.receiver = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	fact := currency.NewChangeCoinOwnerFact(
+		[]byte(cmd.Token), cmd.sender, currency.CoinID(cmd.CoinID), cmd.receiver,
+	)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := currency.NewChangeCoinOwnerOperation(fact, fs, cmd.Memo)
+	if err != nil {
+		return xerrors.Errorf("failed to create change-coin-owner operation: %w", err)
+	}
+
+	sl, err := loadSealAndAddOperation(cmd.Seal.Bytes(), cmd.Privatekey, cmd.NetworkID.Bytes(), op)
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
@@ -27,8 +27,9 @@ type CreateAccountCommand struct {
 	Pretty     bool           `name:"pretty" help:"pretty format"`
 	Memo       string         `name:"memo" help:"memo"`
 	Seal       FileLoad       `help:"seal" optional:""`
-	sender     base.Address
-	keys       currency.Keys
+	SendSealFlags
+	sender base.Address
+	keys   currency.Keys
 }
 
 func NewCreateAccountCommand() CreateAccountCommand {
@@ -64,6 +65,8 @@ func (cmd *CreateAccountCommand) Run(version util.Version) error { // nolint:dup
 		op,
 	); err != nil {
 		return err
+	} else if cmd.SendSealFlags.requested() {
+		return cmd.SendSealFlags.send(cmd.Privatekey, sl)
 	} else {
 		cmd.pretty(cmd.Pretty, sl)
 	}
@@ -0,0 +1,120 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/localtime"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/extension"
+)
+
+type CreateContractAccountCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"owner's privatekey" required:""`
+	Owner      AddressFlag    `arg:"" name:"owner" help:"owner address" required:""`
+	Contract   AddressFlag    `arg:"" name:"contract" help:"new contract account address" required:""`
+	Amount     AmountFlag     `arg:"" name:"amount" help:"initial balance for the contract account" required:""`
+	Handler    []string       `name:"handler" help:"handler method name, repeatable"`
+	Token      string         `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	Memo       string         `name:"memo" help:"memo"`
+	Seal       FileLoad       `help:"seal" optional:""`
+	owner      base.Address
+	contract   base.Address
+}
+
+func NewCreateContractAccountCommand() CreateContractAccountCommand {
+	return CreateContractAccountCommand{
+		BaseCommand: NewBaseCommand("create-contract-account-operation"),
+	}
+}
+
+func (cmd *CreateContractAccountCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if a, err := cmd.Owner.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid owner format, %q: %w", cmd.Owner.String(), err)
+	} else {
+		cmd.owner = a
+	}
+
+	if a, err := cmd.Contract.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid contract format, %q: %w", cmd.Contract.String(), err)
+	} else {
+		cmd.contract = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	op, err := cmd.createOperation()
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.Bytes(),
+		op,
+	)
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
+
+func (cmd *CreateContractAccountCommand) createOperation() (operation.Operation, error) {
+	if len(cmd.Handler) < 1 {
+		return nil, xerrors.Errorf("--handler must be given at least one")
+	}
+
+	handlers := map[string]extension.Handler{}
+	for i := range cmd.Handler {
+		handlers[cmd.Handler[i]] = noopHandler
+	}
+
+	config := extension.NewBaseConfig(handlers)
+	if err := config.IsValid(nil); err != nil {
+		return nil, err
+	}
+
+	fact := extension.NewCreateContractAccountFact(
+		[]byte(cmd.Token), cmd.owner, cmd.contract, cmd.Amount.Amount, config,
+	)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return nil, err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := extension.NewCreateContractAccount(fact, fs, cmd.Memo)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create create-contract-account operation: %w", err)
+	}
+
+	return op, nil
+}
+
+// noopHandler is the placeholder body used when a config is created from the
+// CLI without a real interpreter backing it; the chain operator is expected
+// to replace it with a Config built from their own handler implementations.
+func noopHandler(
+	base.Address,
+	[]string,
+	func(key string) (state.State, bool, error),
+) ([]state.State, error) {
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/localtime"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/extension"
+)
+
+type DeactivateContractAccountCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"owner's privatekey" required:""`
+	Owner      AddressFlag    `arg:"" name:"owner" help:"owner address" required:""`
+	Contract   AddressFlag    `arg:"" name:"contract" help:"contract account address" required:""`
+	Token      string         `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	Memo       string         `name:"memo" help:"memo"`
+	Seal       FileLoad       `help:"seal" optional:""`
+	owner      base.Address
+	contract   base.Address
+}
+
+func NewDeactivateContractAccountCommand() DeactivateContractAccountCommand {
+	return DeactivateContractAccountCommand{
+		BaseCommand: NewBaseCommand("deactivate-contract-account-operation"),
+	}
+}
+
+func (cmd *DeactivateContractAccountCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if a, err := cmd.Owner.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid owner format, %q: %w", cmd.Owner.String(), err)
+	} else {
+		cmd.owner = a
+	}
+
+	if a, err := cmd.Contract.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid contract format, %q: %w", cmd.Contract.String(), err)
+	} else {
+		cmd.contract = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	op, err := cmd.createOperation()
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.Bytes(),
+		op,
+	)
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
+
+func (cmd *DeactivateContractAccountCommand) createOperation() (operation.Operation, error) {
+	fact := extension.NewDeactivateContractAccountFact([]byte(cmd.Token), cmd.owner, cmd.contract)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return nil, err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := extension.NewDeactivateContractAccount(fact, fs, cmd.Memo)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create deactivate-contract-account operation: %w", err)
+	}
+
+	return op, nil
+}
@@ -0,0 +1,70 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+// factSignWeight reports, for each of keys' registered public keys, whether a
+// valid FactSign over fact exists among signs, and the total weight
+// collected so far. A sign whose signature does not verify, or whose signer
+// is not one of keys, is silently ignored rather than rejected outright:
+// inspect-seal and broadcast-seal both need to tolerate a seal that was
+// gathered from several rounds of out-of-order, offline signing.
+func factSignWeight(
+	fact base.Fact,
+	signs []operation.FactSign,
+	keys currency.Keys,
+	networkID base.NetworkID,
+) (uint, map[string]bool) {
+	signedBy := map[string]bool{}
+	for i := range signs {
+		fs := signs[i]
+
+		if err := fs.Signer().Verify(util.ConcatBytesSlice(fact.Hash().Bytes(), networkID), fs.Signature()); err != nil {
+			continue
+		}
+
+		signedBy[fs.Signer().String()] = true
+	}
+
+	var weight uint
+	for i := range keys.Keys() {
+		k := keys.Keys()[i]
+		if signedBy[k.Key().String()] {
+			weight += k.Weight()
+		}
+	}
+
+	return weight, signedBy
+}
+
+func parseKeysAndThreshold(flags []KeyFlag, threshold uint) (currency.Keys, error) {
+	ks := make([]currency.Key, len(flags))
+	for i := range flags {
+		ks[i] = flags[i].Key
+	}
+
+	keys, err := currency.NewKeys(ks, threshold)
+	if err != nil {
+		return currency.Keys{}, err
+	} else if err := keys.IsValid(nil); err != nil {
+		return currency.Keys{}, err
+	}
+
+	return keys, nil
+}
+
+func operationByIndex(sl operation.Seal, index int) (operation.Operation, error) {
+	ops := sl.Operations()
+	if index < 0 || index >= len(ops) {
+		return nil, xerrors.Errorf("operation index %d out of range, seal has %d operation(s)", index, len(ops))
+	}
+
+	return ops[index], nil
+}
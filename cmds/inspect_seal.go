@@ -0,0 +1,75 @@
+package cmds
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+// InspectSealCommand reports, for the Keys set of an N-of-M account, which
+// of its pubkeys have already signed an operation's Fact inside a seal, and
+// how much weight is still needed to reach Threshold. It never mutates the
+// seal; pair it with sign-fact to collect the missing signs and with
+// broadcast-seal once the report shows threshold is met.
+type InspectSealCommand struct {
+	*BaseCommand
+	Seal      FileLoad      `arg:"" name:"seal" help:"seal to inspect, read from stdin if \"-\"" required:""`
+	NetworkID NetworkIDFlag `name:"network-id" help:"network-id" required:""`
+	Key       []KeyFlag     `name:"key" help:"account's registered key (ex: \"<public key>,<weight>\")" sep:"@" required:""` // nolint
+	Threshold uint          `name:"threshold" help:"account's registered threshold" required:""`
+	Operation int           `name:"operation" help:"index of the operation to inspect within the seal" default:"0"`
+}
+
+func NewInspectSealCommand() InspectSealCommand {
+	return InspectSealCommand{
+		BaseCommand: NewBaseCommand("inspect-seal"),
+	}
+}
+
+func (cmd *InspectSealCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	keys, err := parseKeysAndThreshold(cmd.Key, cmd.Threshold)
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSeal(cmd.Seal.Bytes(), cmd.NetworkID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	so, ok := sl.(operation.Seal)
+	if !ok {
+		return xerrors.Errorf("seal is not operation.Seal, %T", sl)
+	}
+
+	op, err := operationByIndex(so, cmd.Operation)
+	if err != nil {
+		return err
+	}
+
+	weight, signedBy := factSignWeight(op.Fact(), op.Signs(), keys, cmd.NetworkID.Bytes())
+
+	w := os.Stdout
+	fmt.Fprintf(w, "fact: %s\n", op.Fact().Hash())
+	for i := range keys.Keys() {
+		k := keys.Keys()[i]
+		fmt.Fprintf(w, "  key %s (weight %d): signed=%t\n", k.Key(), k.Weight(), signedBy[k.Key().String()])
+	}
+	fmt.Fprintf(w, "weight: %d/%d\n", weight, keys.Threshold())
+
+	if remaining := int(keys.Threshold()) - int(weight); remaining > 0 {
+		fmt.Fprintf(w, "remaining weight needed: %d\n", remaining)
+	} else {
+		fmt.Fprintln(w, "threshold met")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmds
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/localtime"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/extension"
+)
+
+type InvokeContractAccountCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"sender's privatekey" required:""`
+	Sender     AddressFlag    `arg:"" name:"sender" help:"sender address" required:""`
+	Contract   AddressFlag    `arg:"" name:"contract" help:"contract account address" required:""`
+	Method     string         `arg:"" name:"method" help:"handler method name" required:""`
+	Args       string         `name:"args" help:"comma-separated handler arguments" optional:""`
+	Token      string         `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+	Memo       string         `name:"memo" help:"memo"`
+	Seal       FileLoad       `help:"seal" optional:""`
+	sender     base.Address
+	contract   base.Address
+}
+
+func NewInvokeContractAccountCommand() InvokeContractAccountCommand {
+	return InvokeContractAccountCommand{
+		BaseCommand: NewBaseCommand("invoke-contract-account-operation"),
+	}
+}
+
+func (cmd *InvokeContractAccountCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if a, err := cmd.Sender.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid sender format, %q: %w", cmd.Sender.String(), err)
+	} else {
+		cmd.sender = a
+	}
+
+	if a, err := cmd.Contract.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid contract format, %q: %w", cmd.Contract.String(), err)
+	} else {
+		cmd.contract = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	op, err := cmd.createOperation()
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.Bytes(),
+		op,
+	)
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
+
+func (cmd *InvokeContractAccountCommand) createOperation() (operation.Operation, error) {
+	var args []string
+	if len(cmd.Args) > 0 {
+		args = strings.Split(cmd.Args, ",")
+	}
+
+	fact := extension.NewInvokeContractAccountFact(
+		[]byte(cmd.Token), cmd.sender, cmd.contract, cmd.Method, args,
+	)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return nil, err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := extension.NewInvokeContractAccount(fact, fs, cmd.Memo)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create invoke-contract-account operation: %w", err)
+	}
+
+	return op, nil
+}
@@ -0,0 +1,555 @@
+package cmds
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/util"
+)
+
+// Every command in this package otherwise takes a raw PrivatekeyFlag on
+// argv, which leaks through shell history and /proc/<pid>/cmdline. The
+// keystore lets an alias stand in for the key instead: PrivatekeyFlag.
+// UnmarshalText (privatekey_flag.go) hands its raw flag text unmodified to
+// ResolveKeystorePrivatekey, so every command in this package accepts
+// "keystore:<alias>" wherever it previously only accepted a plain
+// privatekey string.
+const (
+	keystorePasswordEnv      = "MITUM_KEYSTORE_PASSWORD"
+	keystorePrivatekeyPrefix = "keystore:"
+	keystoreScryptN          = 1 << 15
+	keystoreScryptR          = 8
+	keystoreScryptP          = 1
+	keystoreKeyLen           = 32
+	keystoreSaltLen          = 16
+	minPasswordScore         = 3
+)
+
+type keystoreEntry struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type keystoreFile map[string]keystoreEntry
+
+func defaultKeystorePath() string {
+	if p := os.Getenv("MITUM_KEYSTORE"); len(p) > 0 {
+		return p
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	return filepath.Join(home, ".mitum", "keystore.json")
+}
+
+func loadKeystoreFile(path string) (keystoreFile, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return keystoreFile{}, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("failed to read keystore, %q: %w", path, err)
+	}
+
+	var ks keystoreFile
+	if err := json.Unmarshal(b, &ks); err != nil {
+		return nil, xerrors.Errorf("failed to parse keystore, %q: %w", path, err)
+	}
+
+	return ks, nil
+}
+
+func saveKeystoreFile(path string, ks keystoreFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return xerrors.Errorf("failed to create keystore directory: %w", err)
+	}
+
+	b, err := json.Marshal(ks)
+	if err != nil {
+		return xerrors.Errorf("failed to encode keystore: %w", err)
+	}
+
+	return ioutil.WriteFile(filepath.Clean(path), b, 0o600)
+}
+
+func sealKeystoreEntry(password string, raw []byte) (keystoreEntry, error) {
+	var salt [keystoreSaltLen]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return keystoreEntry{}, err
+	}
+
+	dk, err := scrypt.Key([]byte(password), salt[:], keystoreScryptN, keystoreScryptR, keystoreScryptP, keystoreKeyLen)
+	if err != nil {
+		return keystoreEntry{}, err
+	}
+	var dkey [32]byte
+	copy(dkey[:], dk)
+
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return keystoreEntry{}, err
+	}
+
+	box := secretbox.Seal(nil, raw, &nonce, &dkey)
+
+	return keystoreEntry{Salt: salt[:], Nonce: nonce[:], Ciphertext: box}, nil
+}
+
+func openKeystoreEntry(password string, entry keystoreEntry) ([]byte, error) {
+	dk, err := scrypt.Key(
+		[]byte(password), entry.Salt, keystoreScryptN, keystoreScryptR, keystoreScryptP, keystoreKeyLen,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var dkey [32]byte
+	copy(dkey[:], dk)
+
+	var nonce [24]byte
+	copy(nonce[:], entry.Nonce)
+
+	b, ok := secretbox.Open(nil, entry.Ciphertext, &nonce, &dkey)
+	if !ok {
+		return nil, xerrors.Errorf("wrong password or corrupted keystore entry")
+	}
+
+	return b, nil
+}
+
+// ResolveKeystorePrivatekey is the full decode step a PrivatekeyFlag takes
+// its raw argv text through: anything without the "keystore:" prefix is
+// parsed exactly as it always was, so an unmodified raw privatekey string
+// keeps working; only a "keystore:<alias>" value is intercepted, decrypted
+// from the local keystore, and its plaintext buffer zeroed once parsed.
+func ResolveKeystorePrivatekey(raw string) (key.Privatekey, error) {
+	if !strings.HasPrefix(raw, keystorePrivatekeyPrefix) {
+		return key.DecodePrivatekeyFromString(raw)
+	}
+
+	alias := strings.TrimPrefix(raw, keystorePrivatekeyPrefix)
+
+	ks, err := loadKeystoreFile(defaultKeystorePath())
+	if err != nil {
+		return nil, err
+	}
+
+	entry, found := ks[alias]
+	if !found {
+		return nil, util.NotFoundError.Errorf("keystore alias, %q", alias)
+	}
+
+	password, err := keystorePassword(fmt.Sprintf("password for keystore alias %q: ", alias))
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := openKeystoreEntry(password, entry)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(plain)
+
+	priv, err := key.DecodePrivatekeyFromString(string(plain))
+	if err != nil {
+		return nil, xerrors.Errorf("corrupted keystore entry, %q: %w", alias, err)
+	}
+
+	return priv, nil
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func keystorePassword(prompt string) (string, error) {
+	if p := os.Getenv(keystorePasswordEnv); len(p) > 0 {
+		return p, nil
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", xerrors.Errorf("failed to read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+type KeystoreImportCommand struct {
+	*BaseCommand
+	Alias          string         `arg:"" name:"alias" help:"alias to store the key under" required:""`
+	Privatekey     PrivatekeyFlag `arg:"" name:"privatekey" help:"privatekey to import" required:""`
+	WeakPasswordOk bool           `name:"weak-password-ok" help:"allow a password scoring below the minimum strength"` // nolint
+	KeystorePath   string         `name:"keystore-path" help:"path to the keystore file" optional:""`
+}
+
+func NewKeystoreImportCommand() KeystoreImportCommand {
+	return KeystoreImportCommand{
+		BaseCommand: NewBaseCommand("keystore-import"),
+	}
+}
+
+func (cmd *KeystoreImportCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	path := cmd.KeystorePath
+	if len(path) < 1 {
+		path = defaultKeystorePath()
+	}
+
+	ks, err := loadKeystoreFile(path)
+	if err != nil {
+		return err
+	}
+
+	if _, found := ks[cmd.Alias]; found {
+		return xerrors.Errorf("keystore alias, %q already exists", cmd.Alias)
+	}
+
+	password, err := keystorePassword(fmt.Sprintf("new password for alias %q: ", cmd.Alias))
+	if err != nil {
+		return err
+	}
+
+	score, guesses := passwordStrength(password)
+	if score < minPasswordScore && !cmd.WeakPasswordOk {
+		return xerrors.Errorf(
+			"password too weak, score %d/4 (~%.0f guesses); use --weak-password-ok to override", score, guesses,
+		)
+	}
+
+	entry, err := sealKeystoreEntry(password, []byte(cmd.Privatekey.String()))
+	if err != nil {
+		return xerrors.Errorf("failed to seal privatekey: %w", err)
+	}
+
+	ks[cmd.Alias] = entry
+
+	if err := saveKeystoreFile(path, ks); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "imported %q into keystore, %q\n", cmd.Alias, path)
+
+	return nil
+}
+
+type KeystoreListCommand struct {
+	*BaseCommand
+	KeystorePath string `name:"keystore-path" help:"path to the keystore file" optional:""`
+}
+
+func NewKeystoreListCommand() KeystoreListCommand {
+	return KeystoreListCommand{
+		BaseCommand: NewBaseCommand("keystore-list"),
+	}
+}
+
+func (cmd *KeystoreListCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	path := cmd.KeystorePath
+	if len(path) < 1 {
+		path = defaultKeystorePath()
+	}
+
+	ks, err := loadKeystoreFile(path)
+	if err != nil {
+		return err
+	}
+
+	for alias := range ks {
+		fmt.Fprintln(os.Stdout, alias)
+	}
+
+	return nil
+}
+
+type KeystoreUnlockCommand struct {
+	*BaseCommand
+	Alias        string `arg:"" name:"alias" help:"alias to unlock" required:""`
+	KeystorePath string `name:"keystore-path" help:"path to the keystore file" optional:""`
+}
+
+func NewKeystoreUnlockCommand() KeystoreUnlockCommand {
+	return KeystoreUnlockCommand{
+		BaseCommand: NewBaseCommand("keystore-unlock"),
+	}
+}
+
+// Run only proves that the given password unlocks alias; it prints the
+// publickey, never the privatekey, so it is safe to use as a password
+// check without risking exposure of the key itself.
+func (cmd *KeystoreUnlockCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	path := cmd.KeystorePath
+	if len(path) < 1 {
+		path = defaultKeystorePath()
+	}
+
+	ks, err := loadKeystoreFile(path)
+	if err != nil {
+		return err
+	}
+
+	entry, found := ks[cmd.Alias]
+	if !found {
+		return util.NotFoundError.Errorf("keystore alias, %q", cmd.Alias)
+	}
+
+	password, err := keystorePassword(fmt.Sprintf("password for keystore alias %q: ", cmd.Alias))
+	if err != nil {
+		return err
+	}
+
+	plain, err := openKeystoreEntry(password, entry)
+	if err != nil {
+		return err
+	}
+	defer zeroBytes(plain)
+
+	priv, err := key.DecodePrivatekeyFromString(string(plain))
+	if err != nil {
+		return xerrors.Errorf("corrupted keystore entry, %q: %w", cmd.Alias, err)
+	}
+
+	fmt.Fprintf(os.Stdout, "unlocked %q, publickey: %s\n", cmd.Alias, priv.Publickey())
+
+	return nil
+}
+
+type KeystoreExportCommand struct {
+	*BaseCommand
+	Alias        string `arg:"" name:"alias" help:"alias to export" required:""`
+	KeystorePath string `name:"keystore-path" help:"path to the keystore file" optional:""`
+}
+
+func NewKeystoreExportCommand() KeystoreExportCommand {
+	return KeystoreExportCommand{
+		BaseCommand: NewBaseCommand("keystore-export"),
+	}
+}
+
+// Run deliberately reveals the raw privatekey on stdout; unlike
+// keystore-unlock, it is meant for a one-time backup, not routine use.
+func (cmd *KeystoreExportCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	path := cmd.KeystorePath
+	if len(path) < 1 {
+		path = defaultKeystorePath()
+	}
+
+	ks, err := loadKeystoreFile(path)
+	if err != nil {
+		return err
+	}
+
+	entry, found := ks[cmd.Alias]
+	if !found {
+		return util.NotFoundError.Errorf("keystore alias, %q", cmd.Alias)
+	}
+
+	password, err := keystorePassword(fmt.Sprintf("password for keystore alias %q: ", cmd.Alias))
+	if err != nil {
+		return err
+	}
+
+	plain, err := openKeystoreEntry(password, entry)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stdout, string(plain))
+	zeroBytes(plain)
+
+	return nil
+}
+
+// passwordStrength is a small, self-contained zxcvbn-style estimator: it
+// looks for a handful of common low-entropy patterns (dictionary words,
+// keyboard-adjacency runs, repeated characters, dates) and falls back to a
+// character-class brute-force estimate when nothing matches. It returns the
+// weakest (smallest-guesses) match found, along with a 0-4 score using the
+// same 1e3/1e6/1e8/1e10 guesses thresholds as upstream zxcvbn.
+func passwordStrength(password string) (score int, guesses float64) {
+	guesses = estimateGuesses(password)
+
+	switch {
+	case guesses < 1e3:
+		score = 0
+	case guesses < 1e6:
+		score = 1
+	case guesses < 1e8:
+		score = 2
+	case guesses < 1e10:
+		score = 3
+	default:
+		score = 4
+	}
+
+	return score, guesses
+}
+
+var commonPasswords = []string{
+	"password", "123456", "12345678", "qwerty", "abc123", "letmein",
+	"monkey", "111111", "iloveyou", "admin", "welcome", "dragon",
+	"master", "login", "passw0rd", "trustno1", "sunshine", "princess",
+	"football", "baseball", "superman", "shadow", "michael", "ninja",
+}
+
+var keyboardRuns = []string{
+	"qwertyuiop", "asdfghjkl", "zxcvbnm", "1234567890", "0987654321",
+}
+
+var dateRe = regexp.MustCompile(`^\D*(19|20)\d{2}\D?(0[1-9]|1[0-2])\D?(0[1-9]|[12]\d|3[01])\D*$`)
+
+func estimateGuesses(password string) float64 {
+	if len(password) == 0 {
+		return 1
+	}
+
+	candidates := []float64{bruteForceGuesses(password)}
+
+	lower := strings.ToLower(password)
+
+	for i, word := range commonPasswords {
+		if lower == word {
+			candidates = append(candidates, float64(i+1))
+		}
+	}
+
+	for _, run := range keyboardRuns {
+		if len(password) >= 4 && (strings.Contains(run, lower) || strings.Contains(run, reverseString(lower))) {
+			candidates = append(candidates, float64(len(password)*10))
+		}
+	}
+
+	if period, repeats := repeatedPattern(password); period > 0 && repeats >= 3 {
+		candidates = append(candidates, math.Pow(float64(len(charClasses(password[:period]))), float64(period))*float64(repeats))
+	}
+
+	if dateRe.MatchString(password) {
+		candidates = append(candidates, 365*120)
+	}
+
+	min := candidates[0]
+	for _, g := range candidates[1:] {
+		if g < min {
+			min = g
+		}
+	}
+
+	return min
+}
+
+func bruteForceGuesses(password string) float64 {
+	pool := 0
+	for _, class := range charClasses(password) {
+		pool += class
+	}
+	if pool == 0 {
+		pool = 1
+	}
+
+	return math.Pow(float64(pool), float64(len(password)))
+}
+
+// charClasses returns the pool size contributed by each character class
+// present in s: lowercase, uppercase, digits, and everything else.
+func charClasses(s string) []int {
+	var lower, upper, digit, symbol bool
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	var classes []int
+	if lower {
+		classes = append(classes, 26)
+	}
+	if upper {
+		classes = append(classes, 26)
+	}
+	if digit {
+		classes = append(classes, 10)
+	}
+	if symbol {
+		classes = append(classes, 33)
+	}
+
+	return classes
+}
+
+// repeatedPattern reports the shortest period p for which password is
+// exactly its first p bytes repeated consecutively, and how many times it
+// repeats; it returns 0, 0 when there is no such period.
+func repeatedPattern(password string) (period, repeats int) {
+	n := len(password)
+	for p := 1; p <= n/2; p++ {
+		if n%p != 0 {
+			continue
+		}
+
+		ok := true
+		for i := p; i < n; i++ {
+			if password[i] != password[i%p] {
+				ok = false
+
+				break
+			}
+		}
+
+		if ok {
+			return p, n / p
+		}
+	}
+
+	return 0, 0
+}
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+
+	return string(r)
+}
@@ -0,0 +1,28 @@
+package cmds
+
+import (
+	"github.com/spikeekips/mitum/base/key"
+)
+
+// PrivatekeyFlag is the argv type for every "privatekey" flag/arg in this
+// package. Embedding key.Privatekey lets a PrivatekeyFlag value stand in
+// anywhere a key.Privatekey is expected (signing, Publickey(), and so on)
+// without an unwrapping step at each call site.
+type PrivatekeyFlag struct {
+	key.Privatekey
+}
+
+// UnmarshalText is PrivatekeyFlag's decode step: it is handed the raw argv
+// text unmodified and defers entirely to ResolveKeystorePrivatekey, which
+// knows how to tell a "keystore:<alias>" reference apart from a plain
+// privatekey string.
+func (v *PrivatekeyFlag) UnmarshalText(b []byte) error {
+	k, err := ResolveKeystorePrivatekey(string(b))
+	if err != nil {
+		return err
+	}
+
+	v.Privatekey = k
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmds
+
+import (
+	"net"
+
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/secureconn"
+)
+
+// SendSealFlags is embedded by commands that can push their finished seal
+// straight to a node over a secureconn channel instead of only printing it
+// to stdout. Both Remote and RemotePubkey must be given together; leaving
+// Remote empty keeps the existing stdout-only behaviour.
+type SendSealFlags struct {
+	Remote       string `name:"remote" help:"remote address (host:port) to push the seal to" optional:""`
+	RemotePubkey string `name:"remote-pubkey" help:"remote node's pinned long-term publickey" optional:""`
+}
+
+func (sf SendSealFlags) requested() bool {
+	return len(sf.Remote) > 0
+}
+
+func (sf SendSealFlags) send(privatekey key.Privatekey, sl operation.Seal) error {
+	if len(sf.RemotePubkey) < 1 {
+		return xerrors.Errorf("--remote-pubkey is required with --remote")
+	}
+
+	remote, err := key.DecodePublickeyFromString(sf.RemotePubkey)
+	if err != nil {
+		return xerrors.Errorf("invalid --remote-pubkey, %q: %w", sf.RemotePubkey, err)
+	}
+
+	conn, err := net.Dial("tcp", sf.Remote)
+	if err != nil {
+		return xerrors.Errorf("failed to dial %q: %w", sf.Remote, err)
+	}
+	defer conn.Close()
+
+	sess, err := secureconn.Handshake(conn, privatekey, remote)
+	if err != nil {
+		return xerrors.Errorf("secureconn handshake with %q failed: %w", sf.Remote, err)
+	}
+	defer sess.Close()
+
+	b, err := jenc.Marshal(sl)
+	if err != nil {
+		return xerrors.Errorf("failed to encode seal: %w", err)
+	}
+
+	if err := sess.Send(b); err != nil {
+		return xerrors.Errorf("failed to send seal to %q: %w", sf.Remote, err)
+	}
+
+	return nil
+}
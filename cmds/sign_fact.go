@@ -0,0 +1,104 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+// verifyExistingFactSigns checks every FactSign already on fact against
+// fact's own hash and networkID, the same check factSignWeight applies when
+// inspect-seal/broadcast-seal tally weight. sign-fact has no Keys to weigh
+// those signs against yet, so it cannot tell a sign from an unregistered
+// key apart from one that matters -- but a sign whose signature does not
+// even verify is unambiguously corrupt, and re-emitting the seal with it
+// still attached would only pass the problem on to whoever signs next.
+func verifyExistingFactSigns(fact operation.Fact, signs []operation.FactSign, networkID []byte) error {
+	for i := range signs {
+		fs := signs[i]
+
+		if err := fs.Signer().Verify(util.ConcatBytesSlice(fact.Hash().Bytes(), networkID), fs.Signature()); err != nil {
+			return xerrors.Errorf("existing fact sign by %q does not verify: %w", fs.Signer(), err)
+		}
+	}
+
+	return nil
+}
+
+// SignFactCommand loads a seal holding one or more partially-signed
+// operations, appends a new FactSign from Privatekey to every operation's
+// Fact, and re-emits the seal so the next signer -- or broadcast-seal, once
+// enough weight is collected -- can pick it up in turn. This lets an N-of-M
+// account be controlled by passing a seal around offline instead of through
+// an online coordinator.
+type SignFactCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag `arg:"" name:"privatekey" help:"privatekey to sign with" required:""`
+	Seal       FileLoad       `arg:"" name:"seal" help:"seal to sign, read from stdin if \"-\"" required:""`
+	NetworkID  NetworkIDFlag  `name:"network-id" help:"network-id" required:""`
+	Pretty     bool           `name:"pretty" help:"pretty format"`
+}
+
+func NewSignFactCommand() SignFactCommand {
+	return SignFactCommand{
+		BaseCommand: NewBaseCommand("sign-fact"),
+	}
+}
+
+func (cmd *SignFactCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	sl, err := loadSeal(cmd.Seal.Bytes(), cmd.NetworkID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	so, ok := sl.(operation.Seal)
+	if !ok {
+		return xerrors.Errorf("seal is not operation.Seal, %T", sl)
+	}
+
+	ops := so.Operations()
+	signed := make([]operation.Operation, len(ops))
+	for i := range ops {
+		op := ops[i]
+
+		fsu, ok := op.(operation.FactSignUpdater)
+		if !ok {
+			return xerrors.Errorf("operation is not operation.FactSignUpdater, %T", op)
+		}
+
+		if err := verifyExistingFactSigns(op.Fact(), op.Signs(), cmd.NetworkID.Bytes()); err != nil {
+			return xerrors.Errorf("operation %q: %w", op.Fact().Hash(), err)
+		}
+
+		sig, err := operation.NewFactSignature(cmd.Privatekey, op.Fact(), cmd.NetworkID.Bytes())
+		if err != nil {
+			return xerrors.Errorf("failed to sign fact, %q: %w", op.Fact().Hash(), err)
+		}
+
+		u, err := fsu.AddFactSigns(operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig))
+		if err != nil {
+			return xerrors.Errorf("failed to add fact sign, %q: %w", op.Fact().Hash(), err)
+		}
+
+		signed[i] = u.(operation.Operation)
+	}
+
+	updated, ok := so.(operation.SealUpdater).SetOperations(signed).(operation.Seal)
+	if !ok {
+		return xerrors.Errorf("failed to set signed operations back on seal")
+	}
+
+	s, err := signSeal(updated, cmd.Privatekey, cmd.NetworkID.Bytes())
+	if err != nil {
+		return err
+	}
+
+	cmd.pretty(cmd.Pretty, s.(operation.Seal))
+
+	return nil
+}
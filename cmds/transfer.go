@@ -0,0 +1,109 @@
+package cmds
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/localtime"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/currency"
+)
+
+type TransferCommand struct {
+	*BaseCommand
+	Privatekey PrivatekeyFlag     `arg:"" name:"privatekey" help:"sender's privatekey" required:""`
+	Sender     AddressFlag        `arg:"" name:"sender" help:"sender address" required:""`
+	Token      string             `help:"token for operation" optional:""`
+	NetworkID  NetworkIDFlag      `name:"network-id" help:"network-id" required:""`
+	Item       []TransferItemFlag `name:"item" help:"transfer item (ex: \"<receiver>,<amount>[:<coin id>]\")" sep:"@"`
+	Pretty     bool               `name:"pretty" help:"pretty format"`
+	Memo       string             `name:"memo" help:"memo"`
+	Seal       FileLoad           `help:"seal" optional:""`
+	SendSealFlags
+	sender base.Address
+}
+
+func NewTransferCommand() TransferCommand {
+	return TransferCommand{
+		BaseCommand: NewBaseCommand("transfer-operation"),
+	}
+}
+
+func (cmd *TransferCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return xerrors.Errorf("failed to initialize command: %w", err)
+	}
+
+	if len(cmd.Item) < 1 {
+		return xerrors.Errorf("--item must be given at least one")
+	}
+
+	if a, err := cmd.Sender.Encode(jenc); err != nil {
+		return xerrors.Errorf("invalid sender format, %q: %w", cmd.Sender.String(), err)
+	} else {
+		cmd.sender = a
+	}
+
+	if len(cmd.Token) < 1 {
+		cmd.Token = localtime.String(localtime.Now())
+	}
+
+	op, err := cmd.createOperation()
+	if err != nil {
+		return err
+	}
+
+	sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.Bytes(),
+		op,
+	)
+	if err != nil {
+		return err
+	}
+
+	if cmd.SendSealFlags.requested() {
+		return cmd.SendSealFlags.send(cmd.Privatekey, sl)
+	}
+
+	cmd.pretty(cmd.Pretty, sl)
+
+	return nil
+}
+
+func (cmd *TransferCommand) createOperation() (operation.Operation, error) {
+	items := make([]currency.TransferItem, len(cmd.Item))
+	for i := range cmd.Item {
+		it := cmd.Item[i]
+
+		receiver, err := it.receiver.Encode(jenc)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid receiver format, %q: %w", it.receiver.String(), err)
+		}
+
+		item := currency.NewTransferItem(receiver, it.coinID, it.amount)
+		if err := item.IsValid(nil); err != nil {
+			return nil, err
+		}
+
+		items[i] = item
+	}
+
+	fact := currency.NewTransfersFact([]byte(cmd.Token), cmd.sender, items)
+
+	sig, err := operation.NewFactSignature(cmd.Privatekey, fact, []byte(cmd.NetworkID))
+	if err != nil {
+		return nil, err
+	}
+	fs := []operation.FactSign{operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig)}
+
+	op, err := currency.NewTransfers(fact, fs, cmd.Memo)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create transfers operation: %w", err)
+	}
+
+	return op, nil
+}
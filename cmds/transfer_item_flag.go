@@ -0,0 +1,54 @@
+package cmds
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/currency"
+)
+
+// TransferItemFlag parses "<receiver>,<amount>[:<coin id>]", e.g.
+// "aVJz4cC...,100:FOO" or, for the native coin, "aVJz4cC...,100".
+type TransferItemFlag struct {
+	s        string
+	receiver AddressFlag
+	coinID   currency.CoinID
+	amount   currency.Amount
+}
+
+func (v *TransferItemFlag) UnmarshalText(b []byte) error {
+	v.s = string(b)
+
+	n := strings.SplitN(v.s, ",", 2)
+	if len(n) != 2 {
+		return xerrors.Errorf("invalid transfer item, %q", v.s)
+	}
+
+	var receiver AddressFlag
+	if err := receiver.UnmarshalText([]byte(n[0])); err != nil {
+		return xerrors.Errorf("invalid receiver address, %q: %w", n[0], err)
+	}
+	v.receiver = receiver
+
+	am := n[1]
+	coinID := currency.DefaultCoinID
+	if i := strings.Index(am, ":"); i >= 0 {
+		coinID = currency.CoinID(am[i+1:])
+		am = am[:i]
+	}
+
+	amount, err := currency.NewAmountFromString(am)
+	if err != nil {
+		return xerrors.Errorf("invalid amount, %q: %w", am, err)
+	}
+
+	v.coinID = coinID
+	v.amount = amount
+
+	return nil
+}
+
+func (v *TransferItemFlag) String() string {
+	return v.s
+}
@@ -0,0 +1,699 @@
+package currency
+
+import (
+	"fmt"
+
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+// CoinID identifies a currency by a stable id rather than a display symbol,
+// so a single account can hold balances of more than one coin side by side.
+// The empty CoinID is reserved for DefaultCoinID, the chain's native coin,
+// which continues to live at the legacy StateKeyBalance(address) so states
+// written before coin ids existed still validate.
+type CoinID string
+
+const DefaultCoinID CoinID = ""
+
+func (ci CoinID) String() string {
+	return string(ci)
+}
+
+func (ci CoinID) Bytes() []byte {
+	return []byte(ci)
+}
+
+func (ci CoinID) IsValid([]byte) error {
+	if ci == DefaultCoinID {
+		return nil
+	}
+
+	if len(ci) > 20 {
+		return isvalid.InvalidError.Errorf("too long coin id, %d", len(ci))
+	}
+
+	return nil
+}
+
+func (ci CoinID) Equal(b CoinID) bool {
+	return ci == b
+}
+
+var (
+	CoinStateKeySuffix = ":coin"
+)
+
+// StateKeyCoin returns the state key holding a CoinID's metadata (its owner
+// and display name). It is distinct from the per-account balance key so that
+// a coin's metadata and any single account's holdings of it can change
+// independently.
+func StateKeyCoin(cid CoinID) string {
+	return fmt.Sprintf("%s%s", cid.String(), CoinStateKeySuffix)
+}
+
+// StateKeyCoinBalance returns the per-account, per-coin balance key for any
+// CoinID other than DefaultCoinID. Balances of DefaultCoinID keep using the
+// pre-existing StateKeyBalance(address) so legacy single-currency states
+// continue to validate unchanged.
+func StateKeyCoinBalance(a base.Address, cid CoinID) string {
+	if cid == DefaultCoinID {
+		return StateKeyBalance(a)
+	}
+
+	return fmt.Sprintf("%s-%s:coinbalance", a.String(), cid.String())
+}
+
+var (
+	CoinType = hint.MustNewType(0xb1, 0x01, "mitum-currency-coin")
+	CoinHint = hint.MustHint(CoinType, "0.0.1")
+)
+
+// Coin is the per-CoinID metadata: who may RecreateCoin/ChangeCoinOwner it,
+// and the display name shown by wallets and explorers.
+type Coin struct {
+	id    CoinID
+	owner base.Address
+	name  string
+}
+
+func NewCoin(id CoinID, owner base.Address, name string) Coin {
+	return Coin{id: id, owner: owner, name: name}
+}
+
+func (co Coin) Hint() hint.Hint {
+	return CoinHint
+}
+
+func (co Coin) Bytes() []byte {
+	return util.ConcatBytesSlice(co.id.Bytes(), co.owner.Bytes(), []byte(co.name))
+}
+
+func (co Coin) IsValid([]byte) error {
+	if err := isvalid.Check([]isvalid.IsValider{co.id, co.owner}, nil, false); err != nil {
+		return err
+	}
+
+	if len(co.name) < 1 {
+		return isvalid.InvalidError.Errorf("empty coin name")
+	}
+
+	return nil
+}
+
+func (co Coin) ID() CoinID {
+	return co.id
+}
+
+func (co Coin) Owner() base.Address {
+	return co.owner
+}
+
+func (co Coin) Name() string {
+	return co.name
+}
+
+func (co Coin) SetOwner(owner base.Address) Coin {
+	co.owner = owner
+
+	return co
+}
+
+func StateCoinValue(st state.State) (Coin, error) {
+	v := st.Value()
+	if v == nil {
+		return Coin{}, util.NotFoundError.Errorf("coin not found in State")
+	}
+
+	co, ok := v.Interface().(Coin)
+	if !ok {
+		return Coin{}, xerrors.Errorf("invalid coin value found, %T", v.Interface())
+	}
+
+	return co, nil
+}
+
+func SetStateCoinValue(st state.State, v Coin) (state.State, error) {
+	uv, err := state.NewHintedValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.SetValue(uv)
+}
+
+var (
+	NewCoinFactType = hint.MustNewType(0xb1, 0x02, "mitum-currency-new-coin-operation-fact")
+	NewCoinFactHint = hint.MustHint(NewCoinFactType, "0.0.1")
+	NewCoinOpType   = hint.MustNewType(0xb1, 0x03, "mitum-currency-new-coin-operation")
+	NewCoinOpHint   = hint.MustHint(NewCoinOpType, "0.0.1")
+)
+
+// NewCoinFact registers a brand new CoinID on chain. It is the only way a
+// CoinID starts existing; RecreateCoin and ChangeCoinOwner require it to
+// already exist.
+type NewCoinFact struct {
+	h     valuehash.Hash
+	token []byte
+	owner base.Address
+	id    CoinID
+	name  string
+}
+
+func NewNewCoinFact(token []byte, owner base.Address, id CoinID, name string) NewCoinFact {
+	fact := NewCoinFact{token: token, owner: owner, id: id, name: name}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact NewCoinFact) Hint() hint.Hint {
+	return NewCoinFactHint
+}
+
+func (fact NewCoinFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact NewCoinFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact NewCoinFact) Token() []byte {
+	return fact.token
+}
+
+func (fact NewCoinFact) Bytes() []byte {
+	return util.ConcatBytesSlice(fact.token, fact.owner.Bytes(), fact.id.Bytes(), []byte(fact.name))
+}
+
+func (fact NewCoinFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for NewCoinFact")
+	} else if fact.id == DefaultCoinID {
+		return isvalid.InvalidError.Errorf("empty coin id is reserved for the native coin")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{fact.h, fact.owner, fact.id}, nil, false); err != nil {
+		return err
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact NewCoinFact) Owner() base.Address {
+	return fact.owner
+}
+
+func (fact NewCoinFact) Coin() Coin {
+	return NewCoin(fact.id, fact.owner, fact.name)
+}
+
+func (fact NewCoinFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.owner}, nil
+}
+
+type NewCoinOperation struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewNewCoinOperation(fact NewCoinFact, fs []operation.FactSign, memo string) (NewCoinOperation, error) {
+	bo, err := operation.NewBaseOperationFromFact(NewCoinOpHint, fact, fs)
+	if err != nil {
+		return NewCoinOperation{}, err
+	}
+
+	op := NewCoinOperation{BaseOperation: bo, Memo: memo}
+	op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+func (op NewCoinOperation) Hint() hint.Hint {
+	return NewCoinOpHint
+}
+
+func (op NewCoinOperation) IsValid(networkID []byte) error {
+	if err := IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op NewCoinOperation) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op NewCoinOperation) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := op.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	op.BaseOperation = o.(operation.BaseOperation)
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+type NewCoinProcessor struct {
+	NewCoinOperation
+}
+
+func (ncp *NewCoinProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := ncp.Fact().(NewCoinFact)
+
+	if err := checkExistsAccountState(StateKeyAccount(fact.owner), getState); err != nil {
+		return nil, err
+	}
+
+	if err := checkFactSignsByState(fact.owner, ncp.Signs(), getState); err != nil {
+		return nil, err
+	} else if err := checkSignWeight(fact.owner, ncp.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	switch _, found, err := getState(StateKeyCoin(fact.id)); {
+	case err != nil:
+		return nil, err
+	case found:
+		return nil, util.IgnoreError.Errorf("coin, %q already exists", fact.id)
+	}
+
+	return ncp, nil
+}
+
+func (ncp *NewCoinProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := ncp.Fact().(NewCoinFact)
+
+	st, err := state.NewStateV0(StateKeyCoin(fact.id), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	st, err = SetStateCoinValue(st, fact.Coin())
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(fact.Hash(), st)
+}
+
+var (
+	ChangeCoinOwnerFactType = hint.MustNewType(0xb1, 0x04, "mitum-currency-change-coin-owner-operation-fact")
+	ChangeCoinOwnerFactHint = hint.MustHint(ChangeCoinOwnerFactType, "0.0.1")
+	ChangeCoinOwnerOpType   = hint.MustNewType(0xb1, 0x05, "mitum-currency-change-coin-owner-operation")
+	ChangeCoinOwnerOpHint   = hint.MustHint(ChangeCoinOwnerOpType, "0.0.1")
+)
+
+type ChangeCoinOwnerFact struct {
+	h        valuehash.Hash
+	token    []byte
+	sender   base.Address
+	id       CoinID
+	receiver base.Address
+}
+
+func NewChangeCoinOwnerFact(token []byte, sender base.Address, id CoinID, receiver base.Address) ChangeCoinOwnerFact {
+	fact := ChangeCoinOwnerFact{token: token, sender: sender, id: id, receiver: receiver}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact ChangeCoinOwnerFact) Hint() hint.Hint {
+	return ChangeCoinOwnerFactHint
+}
+
+func (fact ChangeCoinOwnerFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact ChangeCoinOwnerFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact ChangeCoinOwnerFact) Token() []byte {
+	return fact.token
+}
+
+func (fact ChangeCoinOwnerFact) Bytes() []byte {
+	return util.ConcatBytesSlice(fact.token, fact.sender.Bytes(), fact.id.Bytes(), fact.receiver.Bytes())
+}
+
+func (fact ChangeCoinOwnerFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for ChangeCoinOwnerFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{fact.h, fact.sender, fact.id, fact.receiver}, nil, false); err != nil {
+		return err
+	}
+
+	if fact.sender.Equal(fact.receiver) {
+		return isvalid.InvalidError.Errorf("new owner is same with old owner, %q", fact.sender)
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact ChangeCoinOwnerFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact ChangeCoinOwnerFact) ID() CoinID {
+	return fact.id
+}
+
+func (fact ChangeCoinOwnerFact) Receiver() base.Address {
+	return fact.receiver
+}
+
+func (fact ChangeCoinOwnerFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.sender, fact.receiver}, nil
+}
+
+type ChangeCoinOwnerOperation struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewChangeCoinOwnerOperation(
+	fact ChangeCoinOwnerFact,
+	fs []operation.FactSign,
+	memo string,
+) (ChangeCoinOwnerOperation, error) {
+	bo, err := operation.NewBaseOperationFromFact(ChangeCoinOwnerOpHint, fact, fs)
+	if err != nil {
+		return ChangeCoinOwnerOperation{}, err
+	}
+
+	op := ChangeCoinOwnerOperation{BaseOperation: bo, Memo: memo}
+	op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+func (op ChangeCoinOwnerOperation) Hint() hint.Hint {
+	return ChangeCoinOwnerOpHint
+}
+
+func (op ChangeCoinOwnerOperation) IsValid(networkID []byte) error {
+	if err := IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op ChangeCoinOwnerOperation) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op ChangeCoinOwnerOperation) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := op.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	op.BaseOperation = o.(operation.BaseOperation)
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+type ChangeCoinOwnerProcessor struct {
+	ChangeCoinOwnerOperation
+	co Coin
+}
+
+func (ccop *ChangeCoinOwnerProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := ccop.Fact().(ChangeCoinOwnerFact)
+
+	st, err := existsAccountState(StateKeyCoin(fact.id), "coin", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	co, err := StateCoinValue(st)
+	if err != nil {
+		return nil, err
+	} else if !co.Owner().Equal(fact.sender) {
+		return nil, util.IgnoreError.Errorf("sender is not owner of coin, %q", fact.id)
+	}
+
+	if err := checkFactSignsByState(fact.sender, ccop.Signs(), getState); err != nil {
+		return nil, err
+	} else if err := checkSignWeight(fact.sender, ccop.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	if err := checkExistsAccountState(StateKeyAccount(fact.receiver), getState); err != nil {
+		return nil, err
+	}
+
+	ccop.co = co
+
+	return ccop, nil
+}
+
+func (ccop *ChangeCoinOwnerProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := ccop.Fact().(ChangeCoinOwnerFact)
+
+	st, err := state.NewStateV0(StateKeyCoin(fact.id), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	st, err = SetStateCoinValue(st, ccop.co.SetOwner(fact.receiver))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(fact.Hash(), st)
+}
+
+// RecreateCoinFact reissues a coin's metadata (currently just its display
+// name) without disturbing any account's existing balance of it.
+type RecreateCoinFact struct {
+	h     valuehash.Hash
+	token []byte
+	owner base.Address
+	id    CoinID
+	name  string
+}
+
+var (
+	RecreateCoinFactType = hint.MustNewType(0xb1, 0x06, "mitum-currency-recreate-coin-operation-fact")
+	RecreateCoinFactHint = hint.MustHint(RecreateCoinFactType, "0.0.1")
+	RecreateCoinOpType   = hint.MustNewType(0xb1, 0x07, "mitum-currency-recreate-coin-operation")
+	RecreateCoinOpHint   = hint.MustHint(RecreateCoinOpType, "0.0.1")
+)
+
+func NewRecreateCoinFact(token []byte, owner base.Address, id CoinID, name string) RecreateCoinFact {
+	fact := RecreateCoinFact{token: token, owner: owner, id: id, name: name}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact RecreateCoinFact) Hint() hint.Hint {
+	return RecreateCoinFactHint
+}
+
+func (fact RecreateCoinFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact RecreateCoinFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact RecreateCoinFact) Token() []byte {
+	return fact.token
+}
+
+func (fact RecreateCoinFact) Bytes() []byte {
+	return util.ConcatBytesSlice(fact.token, fact.owner.Bytes(), fact.id.Bytes(), []byte(fact.name))
+}
+
+func (fact RecreateCoinFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for RecreateCoinFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{fact.h, fact.owner, fact.id}, nil, false); err != nil {
+		return err
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact RecreateCoinFact) Owner() base.Address {
+	return fact.owner
+}
+
+func (fact RecreateCoinFact) ID() CoinID {
+	return fact.id
+}
+
+func (fact RecreateCoinFact) Name() string {
+	return fact.name
+}
+
+func (fact RecreateCoinFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.owner}, nil
+}
+
+type RecreateCoinOperation struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewRecreateCoinOperation(
+	fact RecreateCoinFact,
+	fs []operation.FactSign,
+	memo string,
+) (RecreateCoinOperation, error) {
+	bo, err := operation.NewBaseOperationFromFact(RecreateCoinOpHint, fact, fs)
+	if err != nil {
+		return RecreateCoinOperation{}, err
+	}
+
+	op := RecreateCoinOperation{BaseOperation: bo, Memo: memo}
+	op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+func (op RecreateCoinOperation) Hint() hint.Hint {
+	return RecreateCoinOpHint
+}
+
+func (op RecreateCoinOperation) IsValid(networkID []byte) error {
+	if err := IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op RecreateCoinOperation) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op RecreateCoinOperation) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := op.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	op.BaseOperation = o.(operation.BaseOperation)
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
+
+type RecreateCoinProcessor struct {
+	RecreateCoinOperation
+	co Coin
+}
+
+func (rcp *RecreateCoinProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := rcp.Fact().(RecreateCoinFact)
+
+	st, err := existsAccountState(StateKeyCoin(fact.id), "coin", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	co, err := StateCoinValue(st)
+	if err != nil {
+		return nil, err
+	} else if !co.Owner().Equal(fact.owner) {
+		return nil, util.IgnoreError.Errorf("owner does not match coin owner, %q", fact.id)
+	}
+
+	if err := checkFactSignsByState(fact.owner, rcp.Signs(), getState); err != nil {
+		return nil, err
+	} else if err := checkSignWeight(fact.owner, rcp.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	rcp.co = co
+
+	return rcp, nil
+}
+
+func (rcp *RecreateCoinProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := rcp.Fact().(RecreateCoinFact)
+
+	st, err := state.NewStateV0(StateKeyCoin(fact.id), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	st, err = SetStateCoinValue(st, NewCoin(fact.id, rcp.co.Owner(), fact.name))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(fact.Hash(), st)
+}
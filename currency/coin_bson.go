@@ -0,0 +1,311 @@
+package currency
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (co Coin) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(co.Hint()),
+		bson.M{
+			"id":    co.id,
+			"owner": co.owner,
+			"name":  co.name,
+		},
+	))
+}
+
+type coinUnpackerBSON struct {
+	ID    CoinID              `bson:"id"`
+	Owner base.AddressDecoder `bson:"owner"`
+	Name  string              `bson:"name"`
+}
+
+func (co *Coin) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uco coinUnpackerBSON
+	if err := enc.Unmarshal(b, &uco); err != nil {
+		return err
+	}
+
+	owner, err := uco.Owner.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	co.id = uco.ID
+	co.owner = owner
+	co.name = uco.Name
+
+	return nil
+}
+
+func (fact NewCoinFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":   fact.h,
+			"token":  fact.token,
+			"owner":  fact.owner,
+			"coinid": fact.id,
+			"name":   fact.name,
+		},
+	))
+}
+
+type newCoinFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	OW base.AddressDecoder `bson:"owner"`
+	ID CoinID              `bson:"coinid"`
+	NM string              `bson:"name"`
+}
+
+func (fact *NewCoinFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf newCoinFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.id = uf.ID
+	fact.name = uf.NM
+
+	return nil
+}
+
+func (op NewCoinOperation) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(op.Hint()),
+		bson.M{
+			"hash":       op.Hash(),
+			"fact":       op.Fact(),
+			"fact_signs": op.Signs(),
+			"memo":       op.Memo,
+		},
+	))
+}
+
+type newCoinOperationUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (op *NewCoinOperation) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uop newCoinOperationUnpackerBSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact NewCoinFact
+	if err := fact.UnpackBSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
+
+func (fact ChangeCoinOwnerFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":     fact.h,
+			"token":    fact.token,
+			"sender":   fact.sender,
+			"coinid":   fact.id,
+			"receiver": fact.receiver,
+		},
+	))
+}
+
+type changeCoinOwnerFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	SD base.AddressDecoder `bson:"sender"`
+	ID CoinID              `bson:"coinid"`
+	RC base.AddressDecoder `bson:"receiver"`
+}
+
+func (fact *ChangeCoinOwnerFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf changeCoinOwnerFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	receiver, err := uf.RC.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.sender = sender
+	fact.id = uf.ID
+	fact.receiver = receiver
+
+	return nil
+}
+
+func (op ChangeCoinOwnerOperation) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(op.Hint()),
+		bson.M{
+			"hash":       op.Hash(),
+			"fact":       op.Fact(),
+			"fact_signs": op.Signs(),
+			"memo":       op.Memo,
+		},
+	))
+}
+
+type changeCoinOwnerOperationUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (op *ChangeCoinOwnerOperation) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uop changeCoinOwnerOperationUnpackerBSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact ChangeCoinOwnerFact
+	if err := fact.UnpackBSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
+
+func (fact RecreateCoinFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":   fact.h,
+			"token":  fact.token,
+			"owner":  fact.owner,
+			"coinid": fact.id,
+			"name":   fact.name,
+		},
+	))
+}
+
+type recreateCoinFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	OW base.AddressDecoder `bson:"owner"`
+	ID CoinID              `bson:"coinid"`
+	NM string              `bson:"name"`
+}
+
+func (fact *RecreateCoinFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf recreateCoinFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.id = uf.ID
+	fact.name = uf.NM
+
+	return nil
+}
+
+func (op RecreateCoinOperation) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(op.Hint()),
+		bson.M{
+			"hash":       op.Hash(),
+			"fact":       op.Fact(),
+			"fact_signs": op.Signs(),
+			"memo":       op.Memo,
+		},
+	))
+}
+
+type recreateCoinOperationUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (op *RecreateCoinOperation) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uop recreateCoinOperationUnpackerBSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact RecreateCoinFact
+	if err := fact.UnpackBSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
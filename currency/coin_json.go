@@ -0,0 +1,353 @@
+package currency
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type coinPackerJSON struct {
+	jsonenc.HintedHead
+	ID    CoinID       `json:"id"`
+	Owner base.Address `json:"owner"`
+	Name  string       `json:"name"`
+}
+
+func (co Coin) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(coinPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(co.Hint()),
+		ID:         co.id,
+		Owner:      co.owner,
+		Name:       co.name,
+	})
+}
+
+type coinUnpackerJSON struct {
+	ID    CoinID              `json:"id"`
+	Owner base.AddressDecoder `json:"owner"`
+	Name  string              `json:"name"`
+}
+
+func (co *Coin) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uco coinUnpackerJSON
+	if err := enc.Unmarshal(b, &uco); err != nil {
+		return err
+	}
+
+	owner, err := uco.Owner.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	co.id = uco.ID
+	co.owner = owner
+	co.name = uco.Name
+
+	return nil
+}
+
+type newCoinFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	OW base.Address   `json:"owner"`
+	ID CoinID         `json:"coinid"`
+	NM string         `json:"name"`
+}
+
+func (fact NewCoinFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(newCoinFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		OW:         fact.owner,
+		ID:         fact.id,
+		NM:         fact.name,
+	})
+}
+
+type newCoinFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	OW base.AddressDecoder `json:"owner"`
+	ID CoinID              `json:"coinid"`
+	NM string              `json:"name"`
+}
+
+func (fact *NewCoinFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf newCoinFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.id = uf.ID
+	fact.name = uf.NM
+
+	return nil
+}
+
+type newCoinOperationPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (op NewCoinOperation) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(newCoinOperationPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(op.Hint()),
+		H:          op.Hash(),
+		FC:         op.Fact(),
+		FS:         op.Signs(),
+		MM:         op.Memo,
+	})
+}
+
+type newCoinOperationUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (op *NewCoinOperation) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uop newCoinOperationUnpackerJSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact NewCoinFact
+	if err := fact.UnpackJSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
+
+type changeCoinOwnerFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	SD base.Address   `json:"sender"`
+	ID CoinID         `json:"coinid"`
+	RC base.Address   `json:"receiver"`
+}
+
+func (fact ChangeCoinOwnerFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(changeCoinOwnerFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		SD:         fact.sender,
+		ID:         fact.id,
+		RC:         fact.receiver,
+	})
+}
+
+type changeCoinOwnerFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	SD base.AddressDecoder `json:"sender"`
+	ID CoinID              `json:"coinid"`
+	RC base.AddressDecoder `json:"receiver"`
+}
+
+func (fact *ChangeCoinOwnerFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf changeCoinOwnerFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	receiver, err := uf.RC.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.sender = sender
+	fact.id = uf.ID
+	fact.receiver = receiver
+
+	return nil
+}
+
+type changeCoinOwnerOperationPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (op ChangeCoinOwnerOperation) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(changeCoinOwnerOperationPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(op.Hint()),
+		H:          op.Hash(),
+		FC:         op.Fact(),
+		FS:         op.Signs(),
+		MM:         op.Memo,
+	})
+}
+
+type changeCoinOwnerOperationUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (op *ChangeCoinOwnerOperation) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uop changeCoinOwnerOperationUnpackerJSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact ChangeCoinOwnerFact
+	if err := fact.UnpackJSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
+
+type recreateCoinFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	OW base.Address   `json:"owner"`
+	ID CoinID         `json:"coinid"`
+	NM string         `json:"name"`
+}
+
+func (fact RecreateCoinFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(recreateCoinFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		OW:         fact.owner,
+		ID:         fact.id,
+		NM:         fact.name,
+	})
+}
+
+type recreateCoinFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	OW base.AddressDecoder `json:"owner"`
+	ID CoinID              `json:"coinid"`
+	NM string              `json:"name"`
+}
+
+func (fact *RecreateCoinFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf recreateCoinFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.id = uf.ID
+	fact.name = uf.NM
+
+	return nil
+}
+
+type recreateCoinOperationPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (op RecreateCoinOperation) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(recreateCoinOperationPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(op.Hint()),
+		H:          op.Hash(),
+		FC:         op.Fact(),
+		FS:         op.Signs(),
+		MM:         op.Memo,
+	})
+}
+
+type recreateCoinOperationUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (op *RecreateCoinOperation) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uop recreateCoinOperationUnpackerJSON
+	if err := enc.Unmarshal(b, &uop); err != nil {
+		return err
+	}
+
+	var fact RecreateCoinFact
+	if err := fact.UnpackJSON(uop.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uop.FS))
+	for i := range uop.FS {
+		fs[i] = uop.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(op.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	op.BaseOperation = bo.SetHash(uop.H)
+	op.Memo = uop.MM
+
+	return nil
+}
@@ -10,38 +10,85 @@ import (
 	"github.com/spikeekips/mitum/util/hint"
 	"github.com/spikeekips/mitum/util/isvalid"
 	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/ProtoconNet/mitum-account-extension-proto/hashenc"
 )
 
 var (
 	TransfersFactType = hint.MustNewType(0xa0, 0x01, "mitum-currency-transfers-operation-fact")
-	TransfersFactHint = hint.MustHint(TransfersFactType, "0.0.1")
-	TransfersType     = hint.MustNewType(0xa0, 0x02, "mitum-currency-transfers-operation")
-	TransfersHint     = hint.MustHint(TransfersType, "0.0.1")
+	// TransfersFactHint is 0.0.2: TransfersFact.Bytes and TransferItem.Bytes
+	// now route through hashenc rather than raw concatenation, so a field
+	// can be added later without reshuffling how any earlier field is read
+	// back. A TransfersFact decoded from the wire keeps whatever hint
+	// version it was actually written with (see TransfersFact.UnpackJSON/
+	// UnpackBSON in transfer_json.go/transfer_bson.go), so a 0.0.1 fact read
+	// back from storage still hashes the old way instead of silently
+	// switching encodings.
+	TransfersFactHint = hint.MustHint(TransfersFactType, "0.0.2")
+	// legacyTransfersFactVersion is the last hint version hashed by
+	// bytesV1; anything at or above 0.0.2 uses hashenc.
+	legacyTransfersFactVersion = util.Version("0.0.1")
+	TransfersType              = hint.MustNewType(0xa0, 0x02, "mitum-currency-transfers-operation")
+	TransfersHint              = hint.MustHint(TransfersType, "0.0.1")
 )
 
 var maxTransferItems uint = 10
 
+// NOTE CreateAccountsFact.Bytes (github.com/spikeekips/mitum-currency/currency)
+// was named alongside TransfersFact/TransferItem in the request that
+// introduced hashenc, but that type belongs to the upstream mitum-currency
+// module, not this repo, so it cannot be migrated from here. It still
+// hashes by plain concatenation.
+
+// IsContractAccountState, when set, reports whether the given address is
+// a contract account. Contract accounts are only moved by the extension
+// package's contract-account operations (InvokeContractAccount handlers and
+// the DeactivateContractAccount balance sweep), so TransfersProcessor
+// refuses to use them as a Transfers sender. It is left nil by default and wired by the
+// extension package, which is the only place that knows about contract
+// accounts.
+var IsContractAccountState func(base.Address, func(key string) (state.State, bool, error)) (bool, error)
+
 type TransferItem struct {
 	receiver base.Address
+	coinID   CoinID
 	amount   Amount
 }
 
-func NewTransferItem(receiver base.Address, amount Amount) TransferItem {
+// NewTransferItem builds a TransferItem for the given CoinID; pass
+// DefaultCoinID to move the chain's native coin.
+func NewTransferItem(receiver base.Address, coinID CoinID, amount Amount) TransferItem {
 	return TransferItem{
 		receiver: receiver,
+		coinID:   coinID,
 		amount:   amount,
 	}
 }
 
+// Bytes has no hint of its own to gate on, since a TransferItem is not an
+// independently hinted type: it always hashes whichever way the enclosing
+// TransfersFact.Bytes chooses.
 func (tff TransferItem) Bytes() []byte {
+	return hashenc.EncodeList(
+		hashenc.EncodeBytes(tff.receiver.Bytes()),
+		hashenc.EncodeBytes(tff.coinID.Bytes()),
+		hashenc.EncodeBytes(tff.amount.Bytes()),
+	)
+}
+
+// bytesV1 is the pre-hashenc, plain-concatenation encoding, kept so a
+// TransferItem decoded from a 0.0.1 TransfersFact still reproduces its
+// original hash.
+func (tff TransferItem) bytesV1() []byte {
 	return util.ConcatBytesSlice(
 		tff.receiver.Bytes(),
+		tff.coinID.Bytes(),
 		tff.amount.Bytes(),
 	)
 }
 
 func (tff TransferItem) IsValid([]byte) error {
-	if err := isvalid.Check([]isvalid.IsValider{tff.receiver, tff.amount}, nil, false); err != nil {
+	if err := isvalid.Check([]isvalid.IsValider{tff.receiver, tff.coinID, tff.amount}, nil, false); err != nil {
 		return err
 	}
 
@@ -56,12 +103,24 @@ func (tff TransferItem) Receiver() base.Address {
 	return tff.receiver
 }
 
+func (tff TransferItem) CoinID() CoinID {
+	return tff.coinID
+}
+
 func (tff TransferItem) Amount() Amount {
 	return tff.amount
 }
 
+// StateKeyBalance returns the per-item balance state key, routing to the
+// legacy single-currency key for DefaultCoinID and to a coin-scoped key for
+// every other CoinID.
+func (tff TransferItem) StateKeyBalance() string {
+	return StateKeyCoinBalance(tff.receiver, tff.coinID)
+}
+
 type TransfersFact struct {
 	h      valuehash.Hash
+	ht     hint.Hint
 	token  []byte
 	sender base.Address
 	items  []TransferItem
@@ -69,6 +128,7 @@ type TransfersFact struct {
 
 func NewTransfersFact(token []byte, sender base.Address, items []TransferItem) TransfersFact {
 	tff := TransfersFact{
+		ht:     TransfersFactHint,
 		token:  token,
 		sender: sender,
 		items:  items,
@@ -78,8 +138,12 @@ func NewTransfersFact(token []byte, sender base.Address, items []TransferItem) T
 	return tff
 }
 
+// Hint returns the hint this fact was actually built or decoded with, not
+// unconditionally the package's current TransfersFactHint: a fact decoded
+// from a 0.0.1 wire value keeps reporting 0.0.1 so Bytes can keep hashing it
+// the 0.0.1 way.
 func (tff TransfersFact) Hint() hint.Hint {
-	return TransfersFactHint
+	return tff.ht
 }
 
 func (tff TransfersFact) Hash() valuehash.Hash {
@@ -95,11 +159,31 @@ func (tff TransfersFact) Token() []byte {
 }
 
 func (tff TransfersFact) Bytes() []byte {
+	if tff.Hint().Version() == legacyTransfersFactVersion {
+		return tff.bytesV1()
+	}
+
 	its := make([][]byte, len(tff.items))
 	for i := range tff.items {
 		its[i] = tff.items[i].Bytes()
 	}
 
+	return hashenc.EncodeList(
+		hashenc.EncodeBytes(tff.token),
+		hashenc.EncodeBytes(tff.sender.Bytes()),
+		hashenc.EncodeList(its...),
+	)
+}
+
+// bytesV1 is the pre-hashenc, plain-concatenation encoding, kept so a
+// TransfersFact decoded with FactHint 0.0.1 still reproduces its original
+// hash.
+func (tff TransfersFact) bytesV1() []byte {
+	its := make([][]byte, len(tff.items))
+	for i := range tff.items {
+		its[i] = tff.items[i].bytesV1()
+	}
+
 	return util.ConcatBytesSlice(
 		tff.token,
 		tff.sender.Bytes(),
@@ -153,6 +237,27 @@ func (tff TransfersFact) Items() []TransferItem {
 	return tff.items
 }
 
+// AmountsByCoin sums the items' amounts grouped by CoinID, since items of a
+// single Transfers may now move more than one coin at once.
+func (tff TransfersFact) AmountsByCoin() map[CoinID]Amount {
+	sums := map[CoinID]Amount{}
+	for i := range tff.items {
+		it := tff.items[i]
+
+		sum, found := sums[it.CoinID()]
+		if !found {
+			sum = NewAmount(0)
+		}
+
+		sums[it.CoinID()] = sum.Add(it.Amount())
+	}
+
+	return sums
+}
+
+// Amount sums every item's amount regardless of CoinID. It is kept for
+// callers still dealing only in the native coin; callers that must respect
+// CoinID boundaries should use AmountsByCoin instead.
 func (tff TransfersFact) Amount() Amount {
 	a := NewAmount(0)
 	for i := range tff.items {
@@ -255,7 +360,7 @@ func (tf *TransferProcessor) PreProcess(
 		return err
 	}
 
-	if st, err := existsAccountState(StateKeyBalance(tf.fact.receiver), "balance of receiver", getState); err != nil {
+	if st, err := existsAccountState(tf.fact.StateKeyBalance(), "balance of receiver", getState); err != nil {
 		return err
 	} else {
 		tf.rb = NewAmountState(st)
@@ -271,27 +376,78 @@ func (tf *TransferProcessor) Process(
 	return tf.rb.Add(tf.fact.Amount()), nil
 }
 
+// checkSignWeight reads sender's registered Keys from state and sums the
+// weight of whichever of them appear among signs, erroring unless that sum
+// meets Keys.Threshold. Every FactSign here already passed
+// checkFactSignsByState, so its signature is not re-verified; only which
+// key signed, and how much that key is worth, matters.
+func checkSignWeight(
+	sender base.Address,
+	signs []operation.FactSign,
+	getState func(key string) (state.State, bool, error),
+) error {
+	st, err := existsAccountState(StateKeyAccount(sender), "keys of sender", getState)
+	if err != nil {
+		return err
+	}
+
+	keys, err := StateKeysValue(st)
+	if err != nil {
+		return err
+	}
+
+	signedBy := map[string]struct{}{}
+	for i := range signs {
+		signedBy[signs[i].Signer().String()] = struct{}{}
+	}
+
+	var weight uint
+	for i := range keys.Keys() {
+		k := keys.Keys()[i]
+		if _, found := signedBy[k.Key().String()]; found {
+			weight += k.Weight()
+		}
+	}
+
+	if weight < keys.Threshold() {
+		return xerrors.Errorf("insufficient signs for sender, weight %d of threshold %d", weight, keys.Threshold())
+	}
+
+	return nil
+}
+
 type TransfersProcessor struct {
 	Transfers
-	fa  FeeAmount
-	sb  AmountState
-	rb  []*TransferProcessor
-	fee Amount
+	fa     FeeAmount
+	sbCoin map[CoinID]AmountState
+	rb     []*TransferProcessor
+	fee    map[CoinID]Amount
 }
 
-func (tf *TransfersProcessor) calculateFee() (Amount, error) {
+// calculateFee sums each item's fee against its own CoinID, since a single
+// Transfers may now move more than one coin at once and every coin is
+// billed out of its own balance.
+func (tf *TransfersProcessor) calculateFee() (map[CoinID]Amount, error) {
 	fact := tf.Fact().(TransfersFact)
 
-	sum := NewAmount(0)
+	fees := map[CoinID]Amount{}
 	for i := range fact.items {
-		if fee, err := tf.fa.Fee(fact.items[i].Amount()); err != nil {
-			return NilAmount, err
-		} else {
-			sum = sum.Add(fee)
+		it := fact.items[i]
+
+		fee, err := tf.fa.Fee(it.Amount())
+		if err != nil {
+			return nil, err
+		}
+
+		sum, found := fees[it.CoinID()]
+		if !found {
+			sum = NewAmount(0)
 		}
+
+		fees[it.CoinID()] = sum.Add(fee)
 	}
 
-	return sum, nil
+	return fees, nil
 }
 
 func (tf *TransfersProcessor) PreProcess(
@@ -304,20 +460,40 @@ func (tf *TransfersProcessor) PreProcess(
 		return nil, err
 	}
 
-	if st, err := existsAccountState(StateKeyBalance(fact.sender), "balance of sender", getState); err != nil {
-		return nil, err
-	} else if fee, err := tf.calculateFee(); err != nil {
-		return nil, util.IgnoreError.Wrap(err)
-	} else {
-		switch b, err := StateAmountValue(st); {
+	if IsContractAccountState != nil {
+		switch isContract, err := IsContractAccountState(fact.sender, getState); {
 		case err != nil:
 			return nil, util.IgnoreError.Wrap(err)
-		case b.Compare(fact.Amount().Add(fee)) < 0:
+		case isContract:
+			return nil, util.IgnoreError.Errorf("contract account cannot be sender of Transfers, %q", fact.sender)
+		}
+	}
+
+	fees, err := tf.calculateFee()
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+	tf.fee = fees
+
+	amounts := fact.AmountsByCoin()
+
+	tf.sbCoin = map[CoinID]AmountState{}
+	for cid, sum := range amounts {
+		need := sum.Add(fees[cid])
+
+		st, err := existsAccountState(StateKeyCoinBalance(fact.sender, cid), "balance of sender", getState)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := StateAmountValue(st)
+		if err != nil {
+			return nil, util.IgnoreError.Wrap(err)
+		} else if b.Compare(need) < 0 {
 			return nil, util.IgnoreError.Errorf("insufficient balance of sender")
-		default:
-			tf.sb = NewAmountState(st)
-			tf.fee = fee
 		}
+
+		tf.sbCoin[cid] = NewAmountState(st)
 	}
 
 	rb := make([]*TransferProcessor, len(fact.items))
@@ -334,6 +510,14 @@ func (tf *TransfersProcessor) PreProcess(
 		return nil, xerrors.Errorf("invalid signing: %w", err)
 	}
 
+	// checkFactSignsByState only confirms every FactSign belongs to one of
+	// the sender's registered keys; it does not know about multisig
+	// weight, so an account with Threshold above its lowest-weighted key
+	// could otherwise be moved by a single insufficient signer.
+	if err := checkSignWeight(fact.sender, tf.Signs(), getState); err != nil {
+		return nil, xerrors.Errorf("invalid signing: %w", err)
+	}
+
 	tf.rb = rb
 
 	return tf, nil
@@ -345,7 +529,7 @@ func (tf *TransfersProcessor) Process(
 ) error {
 	fact := tf.Fact().(TransfersFact)
 
-	sts := make([]state.State, len(tf.rb)+1)
+	sts := make([]state.State, len(tf.rb), len(tf.rb)+len(tf.sbCoin))
 	for i := range tf.rb {
 		if st, err := tf.rb[i].Process(getState, setState); err != nil {
 			return util.IgnoreError.Errorf("failed to process transfer item: %w", err)
@@ -354,7 +538,11 @@ func (tf *TransfersProcessor) Process(
 		}
 	}
 
-	sts[len(sts)-1] = tf.sb.Sub(fact.Amount().Add(tf.fee)).AddFee(tf.fee)
+	amounts := fact.AmountsByCoin()
+	for cid, sum := range amounts {
+		fee := tf.fee[cid]
+		sts = append(sts, tf.sbCoin[cid].Sub(sum.Add(fee)).AddFee(fee))
+	}
 
 	return setState(fact.Hash(), sts...)
 }
@@ -0,0 +1,143 @@
+package currency
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (tff TransferItem) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bson.M{
+		"receiver": tff.receiver,
+		"coinid":   tff.coinID,
+		"amount":   tff.amount,
+	})
+}
+
+type transferItemUnpackerBSON struct {
+	Receiver base.AddressDecoder `bson:"receiver"`
+	CoinID   CoinID              `bson:"coinid"`
+	Amount   Amount              `bson:"amount"`
+}
+
+func (tff *TransferItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uit transferItemUnpackerBSON
+	if err := enc.Unmarshal(b, &uit); err != nil {
+		return err
+	}
+
+	receiver, err := uit.Receiver.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	tff.receiver = receiver
+	tff.coinID = uit.CoinID
+	tff.amount = uit.Amount
+
+	return nil
+}
+
+func (tff TransfersFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(tff.Hint()),
+		bson.M{
+			"hash":   tff.h,
+			"token":  tff.token,
+			"sender": tff.sender,
+			"items":  tff.items,
+		},
+	))
+}
+
+type transfersFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	SD base.AddressDecoder `bson:"sender"`
+	IT []bson.Raw          `bson:"items"`
+}
+
+// UnpackBSON is transfer_json.go's UnpackJSON counterpart: it keeps
+// whatever hint version the wire value actually carried, read directly off
+// the envelope, instead of assuming the package's current
+// TransfersFactHint.
+func (tff *TransfersFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	ht, err := enc.DecodeHint(b)
+	if err != nil {
+		return err
+	}
+
+	var uf transfersFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	items := make([]TransferItem, len(uf.IT))
+	for i := range uf.IT {
+		if err := items[i].UnpackBSON(uf.IT[i], enc); err != nil {
+			return err
+		}
+	}
+
+	tff.h = uf.H
+	tff.ht = ht
+	tff.token = uf.TK
+	tff.sender = sender
+	tff.items = items
+
+	return nil
+}
+
+func (tf Transfers) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(tf.Hint()),
+		bson.M{
+			"hash":       tf.Hash(),
+			"fact":       tf.Fact(),
+			"fact_signs": tf.Signs(),
+			"memo":       tf.Memo,
+		},
+	))
+}
+
+type transfersUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (tf *Transfers) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var utf transfersUnpackerBSON
+	if err := enc.Unmarshal(b, &utf); err != nil {
+		return err
+	}
+
+	var fact TransfersFact
+	if err := fact.UnpackBSON(utf.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(utf.FS))
+	for i := range utf.FS {
+		fs[i] = utf.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(tf.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	tf.BaseOperation = bo.SetHash(utf.H)
+	tf.Memo = utf.MM
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package currency
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type transferItemPackerJSON struct {
+	Receiver base.Address `json:"receiver"`
+	CoinID   CoinID       `json:"coinid"`
+	Amount   Amount       `json:"amount"`
+}
+
+func (tff TransferItem) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(transferItemPackerJSON{
+		Receiver: tff.receiver,
+		CoinID:   tff.coinID,
+		Amount:   tff.amount,
+	})
+}
+
+type transferItemUnpackerJSON struct {
+	Receiver base.AddressDecoder `json:"receiver"`
+	CoinID   CoinID              `json:"coinid"`
+	Amount   Amount              `json:"amount"`
+}
+
+func (tff *TransferItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uit transferItemUnpackerJSON
+	if err := enc.Unmarshal(b, &uit); err != nil {
+		return err
+	}
+
+	receiver, err := uit.Receiver.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	tff.receiver = receiver
+	tff.coinID = uit.CoinID
+	tff.amount = uit.Amount
+
+	return nil
+}
+
+type transfersFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	SD base.Address   `json:"sender"`
+	IT []TransferItem `json:"items"`
+}
+
+func (tff TransfersFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(transfersFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(tff.Hint()),
+		H:          tff.h,
+		TK:         tff.token,
+		SD:         tff.sender,
+		IT:         tff.items,
+	})
+}
+
+type transfersFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	SD base.AddressDecoder `json:"sender"`
+	IT [][]byte            `json:"items"`
+}
+
+// UnpackJSON keeps whatever hint version the wire value actually carried,
+// read directly off the envelope rather than assumed to be the package's
+// current TransfersFactHint: this is what lets Bytes tell a 0.0.1
+// TransfersFact apart from a current one and hash it the old way instead
+// of silently migrating it.
+func (tff *TransfersFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	ht, err := enc.DecodeHint(b)
+	if err != nil {
+		return err
+	}
+
+	var uf transfersFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	items := make([]TransferItem, len(uf.IT))
+	for i := range uf.IT {
+		if err := items[i].UnpackJSON(uf.IT[i], enc); err != nil {
+			return err
+		}
+	}
+
+	tff.h = uf.H
+	tff.ht = ht
+	tff.token = uf.TK
+	tff.sender = sender
+	tff.items = items
+
+	return nil
+}
+
+type transfersPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (tf Transfers) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(transfersPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(tf.Hint()),
+		H:          tf.Hash(),
+		FC:         tf.Fact(),
+		FS:         tf.Signs(),
+		MM:         tf.Memo,
+	})
+}
+
+type transfersUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (tf *Transfers) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var utf transfersUnpackerJSON
+	if err := enc.Unmarshal(b, &utf); err != nil {
+		return err
+	}
+
+	var fact TransfersFact
+	if err := fact.UnpackJSON(utf.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(utf.FS))
+	for i := range utf.FS {
+		fs[i] = utf.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(tf.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	tf.BaseOperation = bo.SetHash(utf.H)
+	tf.Memo = utf.MM
+
+	return nil
+}
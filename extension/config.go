@@ -0,0 +1,107 @@
+package extension
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+)
+
+// Config is the interpreter-facing value stored per contract account. A
+// Config does not mutate state by itself; InvokeContractAccount walks its
+// Handler for the requested method and lets the returned Handler compute the
+// actual state transition, reading state through the getState closure it is
+// given and returning the resulting states.
+type Config interface {
+	hint.Hinter
+	isvalid.IsValider
+	util.Byter
+	Handler(method string) (Handler, bool)
+}
+
+// Handler computes a single state transition of a contract account in
+// response to an InvokeContractAccount call, and returns the resulting
+// states rather than staging them itself: InvokeContractAccountProcessor.
+// Process merges them with its own fee-deduction state and calls setState
+// exactly once, the same way every other processor in this package does.
+// args is the raw argument list given by the invoker; it is up to the
+// Handler to interpret it.
+type Handler func(
+	contract base.Address,
+	args []string,
+	getState func(key string) (state.State, bool, error),
+) ([]state.State, error)
+
+var (
+	BaseConfigType = hint.MustNewType(0xb0, 0x02, "mitum-extension-base-config")
+	BaseConfigHint = hint.MustHint(BaseConfigType, "0.0.1")
+)
+
+// BaseConfig is a minimal Config backed by a static map of named Handlers.
+// It is mainly useful for tests and for the simplest contracts; more
+// elaborate configs can implement Config directly with their own storage
+// and dispatch rules.
+type BaseConfig struct {
+	handlers map[string]Handler
+}
+
+func NewBaseConfig(handlers map[string]Handler) BaseConfig {
+	return BaseConfig{handlers: handlers}
+}
+
+func (cf BaseConfig) Hint() hint.Hint {
+	return BaseConfigHint
+}
+
+// Bytes sorts the handler names before concatenating them: Go randomizes
+// map iteration order, and without a deterministic order here two calls to
+// Bytes() on the same BaseConfig could disagree, making GenerateHash()
+// non-reproducible even within the same process.
+func (cf BaseConfig) Bytes() []byte {
+	ks := make([]string, len(cf.handlers))
+	var i int
+	for k := range cf.handlers {
+		ks[i] = k
+		i++
+	}
+	sort.Strings(ks)
+
+	bs := make([][]byte, len(ks))
+	for i := range ks {
+		bs[i] = []byte(ks[i])
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (cf BaseConfig) IsValid([]byte) error {
+	if len(cf.handlers) < 1 {
+		return isvalid.InvalidError.Errorf("empty handlers in config")
+	}
+
+	return nil
+}
+
+func (cf BaseConfig) Handler(method string) (Handler, bool) {
+	h, found := cf.handlers[method]
+
+	return h, found
+}
+
+var ErrHandlerNotFound = errors.New("handler not found in config")
+
+// unresolvedHandler is what a BaseConfig decoded off the wire fills every
+// Handler slot with: a Handler is a Go closure, so decoding can recover a
+// config's handler names but never the behavior behind them. Invoking one
+// before the chain operator rebinds the real Handlers is itself an error.
+func unresolvedHandler(
+	base.Address,
+	[]string,
+	func(key string) (state.State, bool, error),
+) ([]state.State, error) {
+	return nil, errors.New("handler not resolved: rebind BaseConfig's handlers after decoding")
+}
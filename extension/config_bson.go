@@ -0,0 +1,51 @@
+package extension
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+// MarshalBSON mirrors MarshalJSON: only the handler names are persisted,
+// since a Handler closure has no wire form of its own.
+func (cf BaseConfig) MarshalBSON() ([]byte, error) {
+	ks := make([]string, len(cf.handlers))
+	var i int
+	for k := range cf.handlers {
+		ks[i] = k
+		i++
+	}
+
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(cf.Hint()),
+		bson.M{"handlers": ks},
+	))
+}
+
+// DecodeConfigBSON is DecodeConfig's BSON counterpart.
+func DecodeConfigBSON(b []byte, enc *bsonenc.Encoder) (Config, error) {
+	ht, err := enc.DecodeHint(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ht.Type() {
+	case BaseConfigType:
+		var ucf struct {
+			Handlers []string `bson:"handlers"`
+		}
+		if err := enc.Unmarshal(b, &ucf); err != nil {
+			return nil, err
+		}
+
+		handlers := make(map[string]Handler, len(ucf.Handlers))
+		for i := range ucf.Handlers {
+			handlers[ucf.Handlers[i]] = unresolvedHandler
+		}
+
+		return NewBaseConfig(handlers), nil
+	default:
+		return nil, hint.ErrNotMatchedHint.Errorf("unknown config hint, %q", ht)
+	}
+}
@@ -0,0 +1,58 @@
+package extension
+
+import (
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+type baseConfigPackerJSON struct {
+	jsonenc.HintedHead
+	Handlers []string `json:"handlers"`
+}
+
+// MarshalJSON only carries the handler names: a Handler is a Go closure and
+// has no wire form of its own, so what crosses the network is the config's
+// shape, not its behavior. DecodeConfig rebuilds a BaseConfig from those
+// names with every Handler set to unresolvedHandler; a node that wants to
+// actually invoke this contract must rebind its real Handlers afterward.
+func (cf BaseConfig) MarshalJSON() ([]byte, error) {
+	ks := make([]string, len(cf.handlers))
+	var i int
+	for k := range cf.handlers {
+		ks[i] = k
+		i++
+	}
+
+	return jsonenc.Marshal(baseConfigPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(cf.Hint()),
+		Handlers:   ks,
+	})
+}
+
+// DecodeConfig dispatches a JSON-encoded Config by its embedded hint type.
+// BaseConfig is the only Config implementation this repo ships, so it is
+// the only one decodable here; a chain running a different Config needs
+// its own entry in this dispatch.
+func DecodeConfig(b []byte, enc *jsonenc.Encoder) (Config, error) {
+	ht, err := enc.DecodeHint(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ht.Type() {
+	case BaseConfigType:
+		var ucf baseConfigPackerJSON
+		if err := enc.Unmarshal(b, &ucf); err != nil {
+			return nil, err
+		}
+
+		handlers := make(map[string]Handler, len(ucf.Handlers))
+		for i := range ucf.Handlers {
+			handlers[ucf.Handlers[i]] = unresolvedHandler
+		}
+
+		return NewBaseConfig(handlers), nil
+	default:
+		return nil, hint.ErrNotMatchedHint.Errorf("unknown config hint, %q", ht)
+	}
+}
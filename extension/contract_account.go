@@ -0,0 +1,92 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+)
+
+var (
+	ContractAccountType = hint.MustNewType(0xb0, 0x01, "mitum-extension-contract-account")
+	ContractAccountHint = hint.MustHint(ContractAccountType, "0.0.1")
+)
+
+// contractConfigModel and defaultConfigID are the fixed StateKeyContractAccountConfig
+// coordinates used by the create/update/invoke operations; a contract account has
+// exactly one active config at a time.
+const (
+	contractConfigModel = "contract"
+	defaultConfigID     = "default"
+)
+
+// ContractAccount is the status of an account which is controlled by a
+// Config rather than by signing with its own keys. Its state is written only
+// by CreateContractAccount (as active) and DeactivateContractAccount (as
+// inactive).
+type ContractAccount struct {
+	owner    base.Address
+	isActive bool
+}
+
+func NewContractAccount(owner base.Address, isActive bool) ContractAccount {
+	return ContractAccount{owner: owner, isActive: isActive}
+}
+
+func (ca ContractAccount) Hint() hint.Hint {
+	return ContractAccountHint
+}
+
+func (ca ContractAccount) Bytes() []byte {
+	b := []byte{0x00}
+	if ca.isActive {
+		b[0] = 0x01
+	}
+
+	return util.ConcatBytesSlice(ca.owner.Bytes(), b)
+}
+
+func (ca ContractAccount) IsValid([]byte) error {
+	if ca.owner == nil {
+		return isvalid.InvalidError.Errorf("empty owner of contract account")
+	}
+
+	return ca.owner.IsValid(nil)
+}
+
+func (ca ContractAccount) Owner() base.Address {
+	return ca.owner
+}
+
+func (ca ContractAccount) IsActive() bool {
+	return ca.isActive
+}
+
+func (ca ContractAccount) SetIsActive(active bool) ContractAccount {
+	ca.isActive = active
+
+	return ca
+}
+
+func checkContractAccountIsActive(
+	a base.Address,
+	getState func(key string) (state.State, bool, error),
+) error {
+	st, err := existsState(StateKeyContractAccount(a), "contract account", getState)
+	if err != nil {
+		return err
+	}
+
+	ca, err := StateContractAccountValue(st)
+	if err != nil {
+		return err
+	}
+
+	if !ca.IsActive() {
+		return errors.Errorf("contract account, %q is deactivated", a)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (ca ContractAccount) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(ca.Hint()),
+		bson.M{
+			"owner":     ca.owner,
+			"is_active": ca.isActive,
+		},
+	))
+}
+
+type contractAccountUnpackerBSON struct {
+	Owner    base.AddressDecoder `bson:"owner"`
+	IsActive bool                `bson:"is_active"`
+}
+
+func (ca *ContractAccount) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uca contractAccountUnpackerBSON
+	if err := enc.Unmarshal(b, &uca); err != nil {
+		return err
+	}
+
+	owner, err := uca.Owner.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	ca.owner = owner
+	ca.isActive = uca.IsActive
+
+	return nil
+}
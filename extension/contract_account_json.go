@@ -0,0 +1,42 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type contractAccountPackerJSON struct {
+	jsonenc.HintedHead
+	Owner    base.Address `json:"owner"`
+	IsActive bool         `json:"is_active"`
+}
+
+func (ca ContractAccount) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(contractAccountPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(ca.Hint()),
+		Owner:      ca.owner,
+		IsActive:   ca.isActive,
+	})
+}
+
+type contractAccountUnpackerJSON struct {
+	Owner    base.AddressDecoder `json:"owner"`
+	IsActive bool                `json:"is_active"`
+}
+
+func (ca *ContractAccount) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uca contractAccountUnpackerJSON
+	if err := enc.Unmarshal(b, &uca); err != nil {
+		return err
+	}
+
+	owner, err := uca.Owner.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	ca.owner = owner
+	ca.isActive = uca.IsActive
+
+	return nil
+}
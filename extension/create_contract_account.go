@@ -0,0 +1,290 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+var (
+	CreateContractAccountFactType = hint.MustNewType(0xb0, 0x03, "mitum-extension-create-contract-account-operation-fact")
+	CreateContractAccountFactHint = hint.MustHint(CreateContractAccountFactType, "0.0.1")
+	CreateContractAccountType     = hint.MustNewType(0xb0, 0x04, "mitum-extension-create-contract-account-operation")
+	CreateContractAccountHint     = hint.MustHint(CreateContractAccountType, "0.0.1")
+)
+
+type CreateContractAccountFact struct {
+	h        valuehash.Hash
+	token    []byte
+	owner    base.Address
+	contract base.Address
+	amount   currency.Amount
+	config   Config
+}
+
+func NewCreateContractAccountFact(
+	token []byte,
+	owner, contract base.Address,
+	amount currency.Amount,
+	config Config,
+) CreateContractAccountFact {
+	fact := CreateContractAccountFact{
+		token:    token,
+		owner:    owner,
+		contract: contract,
+		amount:   amount,
+		config:   config,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact CreateContractAccountFact) Hint() hint.Hint {
+	return CreateContractAccountFactHint
+}
+
+func (fact CreateContractAccountFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact CreateContractAccountFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact CreateContractAccountFact) Token() []byte {
+	return fact.token
+}
+
+func (fact CreateContractAccountFact) Bytes() []byte {
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.owner.Bytes(),
+		fact.contract.Bytes(),
+		fact.amount.Bytes(),
+		fact.config.Bytes(),
+	)
+}
+
+func (fact CreateContractAccountFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for CreateContractAccountFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h, fact.owner, fact.contract, fact.amount, fact.config,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	if fact.owner.Equal(fact.contract) {
+		return isvalid.InvalidError.Errorf("contract address is same with owner, %q", fact.owner)
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact CreateContractAccountFact) Owner() base.Address {
+	return fact.owner
+}
+
+func (fact CreateContractAccountFact) Contract() base.Address {
+	return fact.contract
+}
+
+func (fact CreateContractAccountFact) Amount() currency.Amount {
+	return fact.amount
+}
+
+func (fact CreateContractAccountFact) Config() Config {
+	return fact.config
+}
+
+func (fact CreateContractAccountFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.owner, fact.contract}, nil
+}
+
+type CreateContractAccount struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewCreateContractAccount(
+	fact CreateContractAccountFact,
+	fs []operation.FactSign,
+	memo string,
+) (CreateContractAccount, error) {
+	bo, err := operation.NewBaseOperationFromFact(CreateContractAccountHint, fact, fs)
+	if err != nil {
+		return CreateContractAccount{}, err
+	}
+
+	ca := CreateContractAccount{BaseOperation: bo, Memo: memo}
+	ca.BaseOperation = bo.SetHash(ca.GenerateHash())
+
+	return ca, nil
+}
+
+func (ca CreateContractAccount) Hint() hint.Hint {
+	return CreateContractAccountHint
+}
+
+func (ca CreateContractAccount) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(ca.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(ca, networkID)
+}
+
+func (ca CreateContractAccount) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(ca.Signs())+1)
+	for i := range ca.Signs() {
+		bs[i] = ca.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(ca.Memo)
+
+	e := util.ConcatBytesSlice(ca.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (ca CreateContractAccount) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := ca.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	ca.BaseOperation = o.(operation.BaseOperation)
+	ca.BaseOperation = ca.SetHash(ca.GenerateHash())
+
+	return ca, nil
+}
+
+type CreateContractAccountProcessor struct {
+	CreateContractAccount
+	fa FeeAmounter
+	ob currency.AmountState
+}
+
+// FeeAmounter mirrors currency.FeeAmount's Fee method, avoiding a hard
+// dependency on its concrete type so extension can be wired with whatever
+// fee scheme the chain configures.
+type FeeAmounter interface {
+	Fee(currency.Amount) (currency.Amount, error)
+}
+
+func NewCreateContractAccountProcessor(fa FeeAmounter) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		i, ok := op.(CreateContractAccount)
+		if !ok {
+			return nil, errors.Errorf("not CreateContractAccount, %T", op)
+		}
+
+		return &CreateContractAccountProcessor{CreateContractAccount: i, fa: fa}, nil
+	}
+}
+
+func (ccap *CreateContractAccountProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := ccap.Fact().(CreateContractAccountFact)
+
+	if err := checkExistsState(currency.StateKeyAccount(fact.owner), getState); err != nil {
+		return nil, err
+	}
+
+	if err := checkNotExistsState(currency.StateKeyAccount(fact.contract), getState); err != nil {
+		return nil, err
+	}
+
+	if err := checkSignWeight(fact.owner, ccap.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	st, err := existsState(currency.StateKeyBalance(fact.owner), "balance of owner", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := ccap.fa.Fee(fact.amount)
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+
+	b, err := currency.StateAmountValue(st)
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	} else if b.Compare(fact.amount.Add(fee)) < 0 {
+		return nil, util.IgnoreError.Errorf("insufficient balance of owner")
+	}
+
+	ccap.ob = currency.NewAmountState(st)
+
+	return ccap, nil
+}
+
+func (ccap *CreateContractAccountProcessor) Process(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := ccap.Fact().(CreateContractAccountFact)
+
+	fee, err := ccap.fa.Fee(fact.amount)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	accSt, err := state.NewStateV0(currency.StateKeyAccount(fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	balSt, err := state.NewStateV0(currency.StateKeyBalance(fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	balSt, err = currency.SetStateAmountValue(balSt, fact.amount)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	caSt, err := state.NewStateV0(StateKeyContractAccount(fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	caSt, err = SetStateContractAccountValue(caSt, NewContractAccount(fact.owner, true))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	cfSt, err := state.NewStateV0(StateKeyContractAccountConfig(contractConfigModel, defaultConfigID, fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	cfSt, err = setStateContractAccountConfigValue(cfSt, fact.config)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(
+		fact.Hash(),
+		accSt,
+		balSt,
+		caSt,
+		cfSt,
+		ccap.ob.Sub(fact.amount.Add(fee)).AddFee(fee),
+	)
+}
@@ -0,0 +1,112 @@
+package extension
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+func (fact CreateContractAccountFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":     fact.h,
+			"token":    fact.token,
+			"owner":    fact.owner,
+			"contract": fact.contract,
+			"amount":   fact.amount,
+			"config":   fact.config,
+		},
+	))
+}
+
+type createContractAccountFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	OW base.AddressDecoder `bson:"owner"`
+	CA base.AddressDecoder `bson:"contract"`
+	AM currency.Amount     `bson:"amount"`
+	CF bson.Raw            `bson:"config"`
+}
+
+func (fact *CreateContractAccountFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf createContractAccountFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	config, err := DecodeConfigBSON(uf.CF, enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+	fact.amount = uf.AM
+	fact.config = config
+
+	return nil
+}
+
+func (ca CreateContractAccount) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(ca.Hint()),
+		bson.M{
+			"hash":       ca.Hash(),
+			"fact":       ca.Fact(),
+			"fact_signs": ca.Signs(),
+			"memo":       ca.Memo,
+		},
+	))
+}
+
+type createContractAccountUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (ca *CreateContractAccount) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uca createContractAccountUnpackerBSON
+	if err := enc.Unmarshal(b, &uca); err != nil {
+		return err
+	}
+
+	var fact CreateContractAccountFact
+	if err := fact.UnpackBSON(uca.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uca.FS))
+	for i := range uca.FS {
+		fs[i] = uca.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(ca.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	ca.BaseOperation = bo.SetHash(uca.H)
+	ca.Memo = uca.MM
+
+	return nil
+}
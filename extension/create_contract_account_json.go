@@ -0,0 +1,124 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+type createContractAccountFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash  `json:"hash"`
+	TK []byte          `json:"token"`
+	OW base.Address    `json:"owner"`
+	CA base.Address    `json:"contract"`
+	AM currency.Amount `json:"amount"`
+	CF Config          `json:"config"`
+}
+
+func (fact CreateContractAccountFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(createContractAccountFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		OW:         fact.owner,
+		CA:         fact.contract,
+		AM:         fact.amount,
+		CF:         fact.config,
+	})
+}
+
+type createContractAccountFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	OW base.AddressDecoder `json:"owner"`
+	CA base.AddressDecoder `json:"contract"`
+	AM currency.Amount     `json:"amount"`
+	CF []byte              `json:"config"`
+}
+
+func (fact *CreateContractAccountFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf createContractAccountFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	config, err := DecodeConfig(uf.CF, enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+	fact.amount = uf.AM
+	fact.config = config
+
+	return nil
+}
+
+type createContractAccountPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (ca CreateContractAccount) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(createContractAccountPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(ca.Hint()),
+		H:          ca.Hash(),
+		FC:         ca.Fact(),
+		FS:         ca.Signs(),
+		MM:         ca.Memo,
+	})
+}
+
+type createContractAccountUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                  `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (ca *CreateContractAccount) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uca createContractAccountUnpackerJSON
+	if err := enc.Unmarshal(b, &uca); err != nil {
+		return err
+	}
+
+	var fact CreateContractAccountFact
+	if err := fact.UnpackJSON(uca.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uca.FS))
+	for i := range uca.FS {
+		fs[i] = uca.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(ca.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	ca.BaseOperation = bo.SetHash(uca.H)
+	ca.Memo = uca.MM
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+var (
+	DeactivateContractAccountFactType = hint.MustNewType(0xb0, 0x09, "mitum-extension-deactivate-contract-account-operation-fact")
+	DeactivateContractAccountFactHint = hint.MustHint(DeactivateContractAccountFactType, "0.0.1")
+	DeactivateContractAccountType     = hint.MustNewType(0xb0, 0x0a, "mitum-extension-deactivate-contract-account-operation")
+	DeactivateContractAccountHint     = hint.MustHint(DeactivateContractAccountType, "0.0.1")
+)
+
+type DeactivateContractAccountFact struct {
+	h        valuehash.Hash
+	token    []byte
+	owner    base.Address
+	contract base.Address
+}
+
+func NewDeactivateContractAccountFact(token []byte, owner, contract base.Address) DeactivateContractAccountFact {
+	fact := DeactivateContractAccountFact{
+		token:    token,
+		owner:    owner,
+		contract: contract,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact DeactivateContractAccountFact) Hint() hint.Hint {
+	return DeactivateContractAccountFactHint
+}
+
+func (fact DeactivateContractAccountFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact DeactivateContractAccountFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact DeactivateContractAccountFact) Token() []byte {
+	return fact.token
+}
+
+func (fact DeactivateContractAccountFact) Bytes() []byte {
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.owner.Bytes(),
+		fact.contract.Bytes(),
+	)
+}
+
+func (fact DeactivateContractAccountFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for DeactivateContractAccountFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{fact.h, fact.owner, fact.contract}, nil, false); err != nil {
+		return err
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact DeactivateContractAccountFact) Owner() base.Address {
+	return fact.owner
+}
+
+func (fact DeactivateContractAccountFact) Contract() base.Address {
+	return fact.contract
+}
+
+func (fact DeactivateContractAccountFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.owner, fact.contract}, nil
+}
+
+type DeactivateContractAccount struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewDeactivateContractAccount(
+	fact DeactivateContractAccountFact,
+	fs []operation.FactSign,
+	memo string,
+) (DeactivateContractAccount, error) {
+	bo, err := operation.NewBaseOperationFromFact(DeactivateContractAccountHint, fact, fs)
+	if err != nil {
+		return DeactivateContractAccount{}, err
+	}
+
+	da := DeactivateContractAccount{BaseOperation: bo, Memo: memo}
+	da.BaseOperation = bo.SetHash(da.GenerateHash())
+
+	return da, nil
+}
+
+func (da DeactivateContractAccount) Hint() hint.Hint {
+	return DeactivateContractAccountHint
+}
+
+func (da DeactivateContractAccount) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(da.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(da, networkID)
+}
+
+func (da DeactivateContractAccount) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(da.Signs())+1)
+	for i := range da.Signs() {
+		bs[i] = da.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(da.Memo)
+
+	e := util.ConcatBytesSlice(da.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (da DeactivateContractAccount) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := da.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	da.BaseOperation = o.(operation.BaseOperation)
+	da.BaseOperation = da.SetHash(da.GenerateHash())
+
+	return da, nil
+}
+
+// DeactivateContractAccountProcessor deactivates a contract account and
+// sweeps its remaining balance back to the owner; a deactivated contract
+// account can no longer be the target of InvokeContractAccount or
+// UpdateContractAccountConfig.
+type DeactivateContractAccountProcessor struct {
+	DeactivateContractAccount
+	fa FeeAmounter
+	cb currency.AmountState
+	ob currency.AmountState
+}
+
+func NewDeactivateContractAccountProcessor(fa FeeAmounter) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		i, ok := op.(DeactivateContractAccount)
+		if !ok {
+			return nil, errors.Errorf("not DeactivateContractAccount, %T", op)
+		}
+
+		return &DeactivateContractAccountProcessor{DeactivateContractAccount: i, fa: fa}, nil
+	}
+}
+
+func (dap *DeactivateContractAccountProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := dap.Fact().(DeactivateContractAccountFact)
+
+	cast, err := existsState(StateKeyContractAccount(fact.contract), "contract account", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := StateContractAccountValue(cast)
+	if err != nil {
+		return nil, err
+	} else if !ca.Owner().Equal(fact.owner) {
+		return nil, util.IgnoreError.Errorf("owner does not match contract account owner")
+	} else if !ca.IsActive() {
+		return nil, util.IgnoreError.Errorf("contract account, %q is already deactivated", fact.contract)
+	}
+
+	if err := checkSignWeight(fact.owner, dap.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	cbSt, err := existsState(currency.StateKeyBalance(fact.contract), "balance of contract account", getState)
+	if err != nil {
+		return nil, err
+	}
+	dap.cb = currency.NewAmountState(cbSt)
+
+	obSt, err := existsState(currency.StateKeyBalance(fact.owner), "balance of owner", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := dap.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+
+	b, err := currency.StateAmountValue(obSt)
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	} else if b.Compare(fee) < 0 {
+		return nil, util.IgnoreError.Errorf("insufficient balance of owner for fee")
+	}
+
+	dap.ob = currency.NewAmountState(obSt)
+
+	return dap, nil
+}
+
+func (dap *DeactivateContractAccountProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := dap.Fact().(DeactivateContractAccountFact)
+
+	fee, err := dap.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	remain, err := currency.StateAmountValue(dap.cb)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	caSt, err := state.NewStateV0(StateKeyContractAccount(fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	caSt, err = SetStateContractAccountValue(caSt, NewContractAccount(fact.owner, false))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(
+		fact.Hash(),
+		caSt,
+		dap.cb.Sub(remain),
+		dap.ob.Add(remain).Sub(fee).AddFee(fee),
+	)
+}
@@ -0,0 +1,99 @@
+package extension
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (fact DeactivateContractAccountFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":     fact.h,
+			"token":    fact.token,
+			"owner":    fact.owner,
+			"contract": fact.contract,
+		},
+	))
+}
+
+type deactivateContractAccountFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	OW base.AddressDecoder `bson:"owner"`
+	CA base.AddressDecoder `bson:"contract"`
+}
+
+func (fact *DeactivateContractAccountFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf deactivateContractAccountFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+
+	return nil
+}
+
+func (da DeactivateContractAccount) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(da.Hint()),
+		bson.M{
+			"hash":       da.Hash(),
+			"fact":       da.Fact(),
+			"fact_signs": da.Signs(),
+			"memo":       da.Memo,
+		},
+	))
+}
+
+type deactivateContractAccountUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (da *DeactivateContractAccount) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uda deactivateContractAccountUnpackerBSON
+	if err := enc.Unmarshal(b, &uda); err != nil {
+		return err
+	}
+
+	var fact DeactivateContractAccountFact
+	if err := fact.UnpackBSON(uda.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uda.FS))
+	for i := range uda.FS {
+		fs[i] = uda.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(da.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	da.BaseOperation = bo.SetHash(uda.H)
+	da.Memo = uda.MM
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type deactivateContractAccountFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	OW base.Address   `json:"owner"`
+	CA base.Address   `json:"contract"`
+}
+
+func (fact DeactivateContractAccountFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(deactivateContractAccountFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		OW:         fact.owner,
+		CA:         fact.contract,
+	})
+}
+
+type deactivateContractAccountFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	OW base.AddressDecoder `json:"owner"`
+	CA base.AddressDecoder `json:"contract"`
+}
+
+func (fact *DeactivateContractAccountFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf deactivateContractAccountFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+
+	return nil
+}
+
+type deactivateContractAccountPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (da DeactivateContractAccount) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(deactivateContractAccountPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(da.Hint()),
+		H:          da.Hash(),
+		FC:         da.Fact(),
+		FS:         da.Signs(),
+		MM:         da.Memo,
+	})
+}
+
+type deactivateContractAccountUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (da *DeactivateContractAccount) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uda deactivateContractAccountUnpackerJSON
+	if err := enc.Unmarshal(b, &uda); err != nil {
+		return err
+	}
+
+	var fact DeactivateContractAccountFact
+	if err := fact.UnpackJSON(uda.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uda.FS))
+	for i := range uda.FS {
+		fs[i] = uda.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(da.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	da.BaseOperation = bo.SetHash(uda.H)
+	da.Memo = uda.MM
+
+	return nil
+}
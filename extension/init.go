@@ -0,0 +1,28 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+
+	localcurrency "github.com/ProtoconNet/mitum-account-extension-proto/currency"
+)
+
+func init() {
+	localcurrency.IsContractAccountState = func(
+		a base.Address,
+		getState func(key string) (state.State, bool, error),
+	) (bool, error) {
+		st, found, err := getState(StateKeyContractAccount(a))
+		if err != nil {
+			return false, err
+		} else if !found {
+			return false, nil
+		}
+
+		if _, err := StateContractAccountValue(st); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+}
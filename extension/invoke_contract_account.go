@@ -0,0 +1,271 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+var (
+	InvokeContractAccountFactType = hint.MustNewType(0xb0, 0x07, "mitum-extension-invoke-contract-account-operation-fact")
+	InvokeContractAccountFactHint = hint.MustHint(InvokeContractAccountFactType, "0.0.1")
+	InvokeContractAccountType     = hint.MustNewType(0xb0, 0x08, "mitum-extension-invoke-contract-account-operation")
+	InvokeContractAccountHint     = hint.MustHint(InvokeContractAccountType, "0.0.1")
+)
+
+type InvokeContractAccountFact struct {
+	h        valuehash.Hash
+	token    []byte
+	sender   base.Address
+	contract base.Address
+	method   string
+	args     []string
+}
+
+func NewInvokeContractAccountFact(
+	token []byte,
+	sender, contract base.Address,
+	method string,
+	args []string,
+) InvokeContractAccountFact {
+	fact := InvokeContractAccountFact{
+		token:    token,
+		sender:   sender,
+		contract: contract,
+		method:   method,
+		args:     args,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact InvokeContractAccountFact) Hint() hint.Hint {
+	return InvokeContractAccountFactHint
+}
+
+func (fact InvokeContractAccountFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact InvokeContractAccountFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact InvokeContractAccountFact) Token() []byte {
+	return fact.token
+}
+
+func (fact InvokeContractAccountFact) Bytes() []byte {
+	as := make([][]byte, len(fact.args))
+	for i := range fact.args {
+		as[i] = []byte(fact.args[i])
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		fact.contract.Bytes(),
+		[]byte(fact.method),
+		util.ConcatBytesSlice(as...),
+	)
+}
+
+func (fact InvokeContractAccountFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for InvokeContractAccountFact")
+	} else if len(fact.method) < 1 {
+		return isvalid.InvalidError.Errorf("empty method for InvokeContractAccountFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{fact.h, fact.sender, fact.contract}, nil, false); err != nil {
+		return err
+	}
+
+	if fact.sender.Equal(fact.contract) {
+		return isvalid.InvalidError.Errorf("contract address is same with sender, %q", fact.sender)
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact InvokeContractAccountFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact InvokeContractAccountFact) Contract() base.Address {
+	return fact.contract
+}
+
+func (fact InvokeContractAccountFact) Method() string {
+	return fact.method
+}
+
+func (fact InvokeContractAccountFact) Args() []string {
+	return fact.args
+}
+
+func (fact InvokeContractAccountFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.sender, fact.contract}, nil
+}
+
+type InvokeContractAccount struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewInvokeContractAccount(
+	fact InvokeContractAccountFact,
+	fs []operation.FactSign,
+	memo string,
+) (InvokeContractAccount, error) {
+	bo, err := operation.NewBaseOperationFromFact(InvokeContractAccountHint, fact, fs)
+	if err != nil {
+		return InvokeContractAccount{}, err
+	}
+
+	ic := InvokeContractAccount{BaseOperation: bo, Memo: memo}
+	ic.BaseOperation = bo.SetHash(ic.GenerateHash())
+
+	return ic, nil
+}
+
+func (ic InvokeContractAccount) Hint() hint.Hint {
+	return InvokeContractAccountHint
+}
+
+func (ic InvokeContractAccount) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(ic.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(ic, networkID)
+}
+
+func (ic InvokeContractAccount) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(ic.Signs())+1)
+	for i := range ic.Signs() {
+		bs[i] = ic.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(ic.Memo)
+
+	e := util.ConcatBytesSlice(ic.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (ic InvokeContractAccount) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := ic.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	ic.BaseOperation = o.(operation.BaseOperation)
+	ic.BaseOperation = ic.SetHash(ic.GenerateHash())
+
+	return ic, nil
+}
+
+type InvokeContractAccountProcessor struct {
+	InvokeContractAccount
+	fa      FeeAmounter
+	sb      currency.AmountState
+	handler Handler
+}
+
+func NewInvokeContractAccountProcessor(fa FeeAmounter) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		i, ok := op.(InvokeContractAccount)
+		if !ok {
+			return nil, errors.Errorf("not InvokeContractAccount, %T", op)
+		}
+
+		return &InvokeContractAccountProcessor{InvokeContractAccount: i, fa: fa}, nil
+	}
+}
+
+func (icp *InvokeContractAccountProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := icp.Fact().(InvokeContractAccountFact)
+
+	if err := checkContractAccountIsActive(fact.contract, getState); err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+
+	cfSt, err := existsState(StateKeyContractAccountConfig(contractConfigModel, defaultConfigID, fact.contract), "contract account config", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := StateContractAccountConfigValue(cfSt)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, found := config.Handler(fact.method)
+	if !found {
+		return nil, util.IgnoreError.Wrap(ErrHandlerNotFound)
+	}
+	icp.handler = handler
+
+	if err := checkSignWeight(fact.sender, icp.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	st, err := existsState(currency.StateKeyBalance(fact.sender), "balance of sender", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := icp.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+
+	b, err := currency.StateAmountValue(st)
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	} else if b.Compare(fee) < 0 {
+		return nil, util.IgnoreError.Errorf("insufficient balance of sender for fee")
+	}
+
+	icp.sb = currency.NewAmountState(st)
+
+	return icp, nil
+}
+
+func (icp *InvokeContractAccountProcessor) Process(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := icp.Fact().(InvokeContractAccountFact)
+
+	handled, err := icp.handler(fact.contract, fact.args, getState)
+	if err != nil {
+		return util.IgnoreError.Errorf("failed to run config handler, %q: %w", fact.method, err)
+	}
+
+	fee, err := icp.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	sts := make([]state.State, len(handled)+1)
+	copy(sts, handled)
+	sts[len(handled)] = icp.sb.Sub(fee).AddFee(fee)
+
+	return setState(fact.Hash(), sts...)
+}
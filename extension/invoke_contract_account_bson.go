@@ -0,0 +1,105 @@
+package extension
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (fact InvokeContractAccountFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":     fact.h,
+			"token":    fact.token,
+			"sender":   fact.sender,
+			"contract": fact.contract,
+			"method":   fact.method,
+			"args":     fact.args,
+		},
+	))
+}
+
+type invokeContractAccountFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	SD base.AddressDecoder `bson:"sender"`
+	CA base.AddressDecoder `bson:"contract"`
+	MT string              `bson:"method"`
+	AG []string            `bson:"args"`
+}
+
+func (fact *InvokeContractAccountFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf invokeContractAccountFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.sender = sender
+	fact.contract = contract
+	fact.method = uf.MT
+	fact.args = uf.AG
+
+	return nil
+}
+
+func (ic InvokeContractAccount) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(ic.Hint()),
+		bson.M{
+			"hash":       ic.Hash(),
+			"fact":       ic.Fact(),
+			"fact_signs": ic.Signs(),
+			"memo":       ic.Memo,
+		},
+	))
+}
+
+type invokeContractAccountUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (ic *InvokeContractAccount) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uic invokeContractAccountUnpackerBSON
+	if err := enc.Unmarshal(b, &uic); err != nil {
+		return err
+	}
+
+	var fact InvokeContractAccountFact
+	if err := fact.UnpackBSON(uic.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uic.FS))
+	for i := range uic.FS {
+		fs[i] = uic.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(ic.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	ic.BaseOperation = bo.SetHash(uic.H)
+	ic.Memo = uic.MM
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type invokeContractAccountFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	SD base.Address   `json:"sender"`
+	CA base.Address   `json:"contract"`
+	MT string         `json:"method"`
+	AG []string       `json:"args"`
+}
+
+func (fact InvokeContractAccountFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(invokeContractAccountFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		SD:         fact.sender,
+		CA:         fact.contract,
+		MT:         fact.method,
+		AG:         fact.args,
+	})
+}
+
+type invokeContractAccountFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	SD base.AddressDecoder `json:"sender"`
+	CA base.AddressDecoder `json:"contract"`
+	MT string              `json:"method"`
+	AG []string            `json:"args"`
+}
+
+func (fact *InvokeContractAccountFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf invokeContractAccountFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	sender, err := uf.SD.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.sender = sender
+	fact.contract = contract
+	fact.method = uf.MT
+	fact.args = uf.AG
+
+	return nil
+}
+
+type invokeContractAccountPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (ic InvokeContractAccount) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(invokeContractAccountPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(ic.Hint()),
+		H:          ic.Hash(),
+		FC:         ic.Fact(),
+		FS:         ic.Signs(),
+		MM:         ic.Memo,
+	})
+}
+
+type invokeContractAccountUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (ic *InvokeContractAccount) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uic invokeContractAccountUnpackerJSON
+	if err := enc.Unmarshal(b, &uic); err != nil {
+		return err
+	}
+
+	var fact InvokeContractAccountFact
+	if err := fact.UnpackJSON(uic.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uic.FS))
+	for i := range uic.FS {
+		fs[i] = uic.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(ic.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	ic.BaseOperation = bo.SetHash(uic.H)
+	ic.Memo = uic.MM
+
+	return nil
+}
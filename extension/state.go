@@ -9,6 +9,8 @@ import (
 	"github.com/spikeekips/mitum/base/operation"
 	"github.com/spikeekips/mitum/base/state"
 	"github.com/spikeekips/mitum/util"
+
+	"github.com/spikeekips/mitum-currency/currency"
 )
 
 var (
@@ -88,6 +90,20 @@ func checkExistsState(
 	}
 }
 
+func checkNotExistsState(
+	key string,
+	getState func(key string) (state.State, bool, error),
+) error {
+	switch _, found, err := getState(key); {
+	case err != nil:
+		return err
+	case found:
+		return operation.NewBaseReasonError("state, %q already exists", key)
+	default:
+		return nil
+	}
+}
+
 func existsState(
 	k,
 	name string,
@@ -103,6 +119,48 @@ func existsState(
 	}
 }
 
+// checkSignWeight reads owner's registered Keys from the upstream currency
+// account state and sums the weight of whichever of them appear among
+// signs, erroring unless that sum meets Keys.Threshold. It is the
+// extension-package counterpart of currency.TransfersProcessor's own
+// sign-weight check, so that naming an owner/sender address in a
+// CreateContractAccount, UpdateContractAccountConfig, InvokeContractAccount
+// or DeactivateContractAccount fact is not by itself enough to authorize it.
+func checkSignWeight(
+	owner base.Address,
+	signs []operation.FactSign,
+	getState func(key string) (state.State, bool, error),
+) error {
+	st, err := existsState(currency.StateKeyAccount(owner), "keys of owner", getState)
+	if err != nil {
+		return err
+	}
+
+	keys, err := currency.StateKeysValue(st)
+	if err != nil {
+		return err
+	}
+
+	signedBy := map[string]struct{}{}
+	for i := range signs {
+		signedBy[signs[i].Signer().String()] = struct{}{}
+	}
+
+	var weight uint
+	for i := range keys.Keys() {
+		k := keys.Keys()[i]
+		if _, found := signedBy[k.Key().String()]; found {
+			weight += k.Weight()
+		}
+	}
+
+	if weight < keys.Threshold() {
+		return errors.Errorf("insufficient signs for owner, weight %d of threshold %d", weight, keys.Threshold())
+	}
+
+	return nil
+}
+
 func notExistsState(
 	k,
 	name string,
@@ -0,0 +1,247 @@
+package extension
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+
+	"github.com/spikeekips/mitum-currency/currency"
+)
+
+var (
+	UpdateContractAccountConfigFactType = hint.MustNewType(0xb0, 0x05, "mitum-extension-update-contract-account-config-operation-fact")
+	UpdateContractAccountConfigFactHint = hint.MustHint(UpdateContractAccountConfigFactType, "0.0.1")
+	UpdateContractAccountConfigType     = hint.MustNewType(0xb0, 0x06, "mitum-extension-update-contract-account-config-operation")
+	UpdateContractAccountConfigHint     = hint.MustHint(UpdateContractAccountConfigType, "0.0.1")
+)
+
+type UpdateContractAccountConfigFact struct {
+	h        valuehash.Hash
+	token    []byte
+	owner    base.Address
+	contract base.Address
+	config   Config
+}
+
+func NewUpdateContractAccountConfigFact(
+	token []byte,
+	owner, contract base.Address,
+	config Config,
+) UpdateContractAccountConfigFact {
+	fact := UpdateContractAccountConfigFact{
+		token:    token,
+		owner:    owner,
+		contract: contract,
+		config:   config,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact UpdateContractAccountConfigFact) Hint() hint.Hint {
+	return UpdateContractAccountConfigFactHint
+}
+
+func (fact UpdateContractAccountConfigFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact UpdateContractAccountConfigFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact UpdateContractAccountConfigFact) Token() []byte {
+	return fact.token
+}
+
+func (fact UpdateContractAccountConfigFact) Bytes() []byte {
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.owner.Bytes(),
+		fact.contract.Bytes(),
+		fact.config.Bytes(),
+	)
+}
+
+func (fact UpdateContractAccountConfigFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return isvalid.InvalidError.Errorf("empty token for UpdateContractAccountConfigFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h, fact.owner, fact.contract, fact.config,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact UpdateContractAccountConfigFact) Owner() base.Address {
+	return fact.owner
+}
+
+func (fact UpdateContractAccountConfigFact) Contract() base.Address {
+	return fact.contract
+}
+
+func (fact UpdateContractAccountConfigFact) Config() Config {
+	return fact.config
+}
+
+func (fact UpdateContractAccountConfigFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.owner, fact.contract}, nil
+}
+
+type UpdateContractAccountConfig struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewUpdateContractAccountConfig(
+	fact UpdateContractAccountConfigFact,
+	fs []operation.FactSign,
+	memo string,
+) (UpdateContractAccountConfig, error) {
+	bo, err := operation.NewBaseOperationFromFact(UpdateContractAccountConfigHint, fact, fs)
+	if err != nil {
+		return UpdateContractAccountConfig{}, err
+	}
+
+	uc := UpdateContractAccountConfig{BaseOperation: bo, Memo: memo}
+	uc.BaseOperation = bo.SetHash(uc.GenerateHash())
+
+	return uc, nil
+}
+
+func (uc UpdateContractAccountConfig) Hint() hint.Hint {
+	return UpdateContractAccountConfigHint
+}
+
+func (uc UpdateContractAccountConfig) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(uc.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(uc, networkID)
+}
+
+func (uc UpdateContractAccountConfig) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(uc.Signs())+1)
+	for i := range uc.Signs() {
+		bs[i] = uc.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(uc.Memo)
+
+	e := util.ConcatBytesSlice(uc.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (uc UpdateContractAccountConfig) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	o, err := uc.BaseOperation.AddFactSigns(fs...)
+	if err != nil {
+		return nil, err
+	}
+	uc.BaseOperation = o.(operation.BaseOperation)
+	uc.BaseOperation = uc.SetHash(uc.GenerateHash())
+
+	return uc, nil
+}
+
+type UpdateContractAccountConfigProcessor struct {
+	UpdateContractAccountConfig
+	fa FeeAmounter
+	ob currency.AmountState
+}
+
+func NewUpdateContractAccountConfigProcessor(fa FeeAmounter) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		i, ok := op.(UpdateContractAccountConfig)
+		if !ok {
+			return nil, errors.Errorf("not UpdateContractAccountConfig, %T", op)
+		}
+
+		return &UpdateContractAccountConfigProcessor{UpdateContractAccountConfig: i, fa: fa}, nil
+	}
+}
+
+func (ucp *UpdateContractAccountConfigProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := ucp.Fact().(UpdateContractAccountConfigFact)
+
+	cast, err := existsState(StateKeyContractAccount(fact.contract), "contract account", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := StateContractAccountValue(cast)
+	if err != nil {
+		return nil, err
+	} else if !ca.Owner().Equal(fact.owner) {
+		return nil, util.IgnoreError.Errorf("owner does not match contract account owner")
+	} else if !ca.IsActive() {
+		return nil, util.IgnoreError.Errorf("contract account, %q is deactivated", fact.contract)
+	}
+
+	if err := checkSignWeight(fact.owner, ucp.Signs(), getState); err != nil {
+		return nil, err
+	}
+
+	st, err := existsState(currency.StateKeyBalance(fact.owner), "balance of owner", getState)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := ucp.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	}
+
+	b, err := currency.StateAmountValue(st)
+	if err != nil {
+		return nil, util.IgnoreError.Wrap(err)
+	} else if b.Compare(fee) < 0 {
+		return nil, util.IgnoreError.Errorf("insufficient balance of owner for fee")
+	}
+
+	ucp.ob = currency.NewAmountState(st)
+
+	return ucp, nil
+}
+
+func (ucp *UpdateContractAccountConfigProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := ucp.Fact().(UpdateContractAccountConfigFact)
+
+	fee, err := ucp.fa.Fee(currency.NewAmount(0))
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	cfSt, err := state.NewStateV0(StateKeyContractAccountConfig(contractConfigModel, defaultConfigID, fact.contract), nil, base.NilHeight)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+	cfSt, err = setStateContractAccountConfigValue(cfSt, fact.config)
+	if err != nil {
+		return util.IgnoreError.Wrap(err)
+	}
+
+	return setState(fact.Hash(), cfSt, ucp.ob.Sub(fee).AddFee(fee))
+}
@@ -0,0 +1,107 @@
+package extension
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (fact UpdateContractAccountConfigFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(fact.Hint()),
+		bson.M{
+			"hash":     fact.h,
+			"token":    fact.token,
+			"owner":    fact.owner,
+			"contract": fact.contract,
+			"config":   fact.config,
+		},
+	))
+}
+
+type updateContractAccountConfigFactUnpackerBSON struct {
+	H  valuehash.Bytes     `bson:"hash"`
+	TK []byte              `bson:"token"`
+	OW base.AddressDecoder `bson:"owner"`
+	CA base.AddressDecoder `bson:"contract"`
+	CF bson.Raw            `bson:"config"`
+}
+
+func (fact *UpdateContractAccountConfigFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uf updateContractAccountConfigFactUnpackerBSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	config, err := DecodeConfigBSON(uf.CF, enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+	fact.config = config
+
+	return nil
+}
+
+func (uc UpdateContractAccountConfig) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(uc.Hint()),
+		bson.M{
+			"hash":       uc.Hash(),
+			"fact":       uc.Fact(),
+			"fact_signs": uc.Signs(),
+			"memo":       uc.Memo,
+		},
+	))
+}
+
+type updateContractAccountConfigUnpackerBSON struct {
+	H  valuehash.Bytes          `bson:"hash"`
+	FC bson.Raw                 `bson:"fact"`
+	FS []operation.BaseFactSign `bson:"fact_signs"`
+	MM string                   `bson:"memo"`
+}
+
+func (uc *UpdateContractAccountConfig) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uuc updateContractAccountConfigUnpackerBSON
+	if err := enc.Unmarshal(b, &uuc); err != nil {
+		return err
+	}
+
+	var fact UpdateContractAccountConfigFact
+	if err := fact.UnpackBSON(uuc.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uuc.FS))
+	for i := range uuc.FS {
+		fs[i] = uuc.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(uc.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	uc.BaseOperation = bo.SetHash(uuc.H)
+	uc.Memo = uuc.MM
+
+	return nil
+}
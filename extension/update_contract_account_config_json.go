@@ -0,0 +1,118 @@
+package extension
+
+import (
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type updateContractAccountConfigFactPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	OW base.Address   `json:"owner"`
+	CA base.Address   `json:"contract"`
+	CF Config         `json:"config"`
+}
+
+func (fact UpdateContractAccountConfigFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(updateContractAccountConfigFactPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		OW:         fact.owner,
+		CA:         fact.contract,
+		CF:         fact.config,
+	})
+}
+
+type updateContractAccountConfigFactUnpackerJSON struct {
+	H  valuehash.Bytes     `json:"hash"`
+	TK []byte              `json:"token"`
+	OW base.AddressDecoder `json:"owner"`
+	CA base.AddressDecoder `json:"contract"`
+	CF []byte              `json:"config"`
+}
+
+func (fact *UpdateContractAccountConfigFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uf updateContractAccountConfigFactUnpackerJSON
+	if err := enc.Unmarshal(b, &uf); err != nil {
+		return err
+	}
+
+	owner, err := uf.OW.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	contract, err := uf.CA.Encode(enc)
+	if err != nil {
+		return err
+	}
+
+	config, err := DecodeConfig(uf.CF, enc)
+	if err != nil {
+		return err
+	}
+
+	fact.h = uf.H
+	fact.token = uf.TK
+	fact.owner = owner
+	fact.contract = contract
+	fact.config = config
+
+	return nil
+}
+
+type updateContractAccountConfigPackerJSON struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash       `json:"hash"`
+	FC operation.Fact       `json:"fact"`
+	FS []operation.FactSign `json:"fact_signs"`
+	MM string               `json:"memo"`
+}
+
+func (uc UpdateContractAccountConfig) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(updateContractAccountConfigPackerJSON{
+		HintedHead: jsonenc.NewHintedHead(uc.Hint()),
+		H:          uc.Hash(),
+		FC:         uc.Fact(),
+		FS:         uc.Signs(),
+		MM:         uc.Memo,
+	})
+}
+
+type updateContractAccountConfigUnpackerJSON struct {
+	H  valuehash.Bytes          `json:"hash"`
+	FC []byte                   `json:"fact"`
+	FS []operation.BaseFactSign `json:"fact_signs"`
+	MM string                   `json:"memo"`
+}
+
+func (uc *UpdateContractAccountConfig) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var uuc updateContractAccountConfigUnpackerJSON
+	if err := enc.Unmarshal(b, &uuc); err != nil {
+		return err
+	}
+
+	var fact UpdateContractAccountConfigFact
+	if err := fact.UnpackJSON(uuc.FC, enc); err != nil {
+		return err
+	}
+
+	fs := make([]operation.FactSign, len(uuc.FS))
+	for i := range uuc.FS {
+		fs[i] = uuc.FS[i]
+	}
+
+	bo, err := operation.NewBaseOperationFromFact(uc.Hint(), fact, fs)
+	if err != nil {
+		return err
+	}
+
+	uc.BaseOperation = bo.SetHash(uuc.H)
+	uc.Memo = uuc.MM
+
+	return nil
+}
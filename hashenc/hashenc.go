@@ -0,0 +1,173 @@
+// Package hashenc provides a small, length-prefixed canonical byte encoding
+// for building the inputs to Fact.GenerateHash(). Plain concatenation (as
+// done by util.ConcatBytesSlice) cannot safely grow: inserting or widening a
+// field shifts every byte after it, silently changing every historical
+// fact hash that happened to follow. Encoding each field as
+// tag + varint(length) + payload keeps fields self-delimiting, so a field
+// can be added at the end of a Bytes() method without disturbing how any
+// earlier field is read back, and a verifier can walk the stream without
+// knowing the schema in advance.
+package hashenc
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/xerrors"
+)
+
+type Tag byte
+
+const (
+	// TagBytes marks a present value, including a present-but-empty one.
+	TagBytes Tag = 0x01
+	// TagNil marks a value that is explicitly nil, as opposed to empty or
+	// simply not written to the stream at all ("missing").
+	TagNil Tag = 0x02
+	// TagList marks a list of already-encoded items.
+	TagList Tag = 0x03
+)
+
+// EncodeBytes encodes a single field. A nil slice and an empty, non-nil
+// slice are encoded differently (TagNil vs TagBytes with a zero length), so
+// a verifier can tell "field was explicitly cleared" from "field is empty".
+func EncodeBytes(b []byte) []byte {
+	tag := TagBytes
+	if b == nil {
+		tag = TagNil
+	}
+
+	var lb [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lb[:], uint64(len(b)))
+
+	buf := make([]byte, 0, 1+n+len(b))
+	buf = append(buf, byte(tag))
+	buf = append(buf, lb[:n]...)
+	buf = append(buf, b...)
+
+	return buf
+}
+
+// EncodeList encodes a list of items that have each already been produced
+// by EncodeBytes/EncodeList, so the list is self-delimiting without needing
+// to know how many bytes any individual item took.
+func EncodeList(items ...[]byte) []byte {
+	var lb [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lb[:], uint64(len(items)))
+
+	buf := make([]byte, 0, 1+n)
+	buf = append(buf, byte(TagList))
+	buf = append(buf, lb[:n]...)
+	for i := range items {
+		buf = append(buf, items[i]...)
+	}
+
+	return buf
+}
+
+// DecodeBytes reads a single EncodeBytes field from the front of b and
+// returns its value (nil if the field was TagNil), along with the
+// unconsumed remainder of b.
+func DecodeBytes(b []byte) (value, rest []byte, _ error) {
+	if len(b) < 1 {
+		return nil, nil, xerrors.Errorf("empty input")
+	}
+
+	tag := Tag(b[0])
+	if tag != TagBytes && tag != TagNil {
+		return nil, nil, xerrors.Errorf("unexpected tag for EncodeBytes field, %#x", b[0])
+	}
+
+	n, m := binary.Uvarint(b[1:])
+	if m <= 0 {
+		return nil, nil, xerrors.Errorf("invalid length varint")
+	}
+
+	start := 1 + m
+	end := start + int(n)
+	if end > len(b) {
+		return nil, nil, xerrors.Errorf("truncated field, need %d bytes, have %d", end, len(b))
+	}
+
+	if tag == TagNil {
+		return nil, b[end:], nil
+	}
+
+	return b[start:end], b[end:], nil
+}
+
+// DecodeList reads a single EncodeList field from the front of b and
+// returns the raw, still-encoded bytes of each item (call DecodeBytes or
+// DecodeList again on each, as the schema dictates), along with the
+// unconsumed remainder of b.
+func DecodeList(b []byte) (items [][]byte, rest []byte, _ error) {
+	if len(b) < 1 {
+		return nil, nil, xerrors.Errorf("empty input")
+	}
+
+	if Tag(b[0]) != TagList {
+		return nil, nil, xerrors.Errorf("unexpected tag for EncodeList field, %#x", b[0])
+	}
+
+	count, m := binary.Uvarint(b[1:])
+	if m <= 0 {
+		return nil, nil, xerrors.Errorf("invalid count varint")
+	}
+
+	cursor := b[1+m:]
+	items = make([][]byte, count)
+	for i := range items {
+		item, next, err := decodeOne(cursor)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("failed to decode list item %d: %w", i, err)
+		}
+
+		items[i] = item
+		cursor = next
+	}
+
+	return items, cursor, nil
+}
+
+// decodeOne consumes exactly one encoded field, bytes or list, and returns
+// its full encoded form (tag, length/count, and payload) rather than the
+// unwrapped value, since the caller is responsible for knowing which of
+// DecodeBytes/DecodeList applies.
+func decodeOne(b []byte) (encoded, rest []byte, _ error) {
+	if len(b) < 1 {
+		return nil, nil, xerrors.Errorf("empty input")
+	}
+
+	switch Tag(b[0]) {
+	case TagBytes, TagNil:
+		n, m := binary.Uvarint(b[1:])
+		if m <= 0 {
+			return nil, nil, xerrors.Errorf("invalid length varint")
+		}
+
+		end := 1 + m + int(n)
+		if end > len(b) {
+			return nil, nil, xerrors.Errorf("truncated field, need %d bytes, have %d", end, len(b))
+		}
+
+		return b[:end], b[end:], nil
+	case TagList:
+		count, m := binary.Uvarint(b[1:])
+		if m <= 0 {
+			return nil, nil, xerrors.Errorf("invalid count varint")
+		}
+
+		cursor := b[1+m:]
+		for i := uint64(0); i < count; i++ {
+			_, next, err := decodeOne(cursor)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			cursor = next
+		}
+
+		return b[:len(b)-len(cursor)], cursor, nil
+	default:
+		return nil, nil, xerrors.Errorf("unknown tag, %#x", b[0])
+	}
+}
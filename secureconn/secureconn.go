@@ -0,0 +1,249 @@
+// Package secureconn implements a station-to-station (STS) authenticated
+// channel over any io.ReadWriteCloser, so a seal built by the cmds CLI can
+// be pushed to a node without depending on TLS PKI. Each side contributes an
+// ephemeral X25519 keypair and signs the transcript with its long-term
+// mitum key, giving the channel both forward secrecy (the ephemeral keys
+// are never persisted) and mutual authentication (the long-term signature
+// ties the ephemeral exchange to a pinned identity).
+package secureconn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/xerrors"
+
+	"github.com/spikeekips/mitum/base/key"
+)
+
+const (
+	maxFrameSize  = 1 << 20
+	hkdfInfoLabel = "mitum-secureconn-v1"
+)
+
+// Session is an established, authenticated, encrypted channel. Send and
+// Receive exchange whole frames; the wire format is unrelated to whatever
+// is carried inside them (the cmds package puts a JSON-encoded seal there).
+type Session struct {
+	rwc io.ReadWriteCloser
+
+	sendKey   [32]byte
+	recvKey   [32]byte
+	sendNonce uint64
+	recvNonce uint64
+}
+
+// Handshake runs the STS exchange over rwc and returns an authenticated
+// Session. local signs the transcript with its long-term key; remote is the
+// pinned long-term publickey the peer is expected to prove ownership of.
+// Handshake fails closed: any verification failure or malformed frame
+// returns an error without yielding a Session.
+func Handshake(rwc io.ReadWriteCloser, local key.Privatekey, remote key.Publickey) (*Session, error) {
+	ephPub, ephPriv, err := newEphemeralKeypair()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to generate ephemeral keypair: %w", err)
+	}
+
+	if err := writeFrame(rwc, ephPub[:]); err != nil {
+		return nil, xerrors.Errorf("failed to send ephemeral pubkey: %w", err)
+	}
+
+	peerEphPub, err := readFrame(rwc)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to receive ephemeral pubkey: %w", err)
+	} else if len(peerEphPub) != 32 {
+		return nil, xerrors.Errorf("invalid ephemeral pubkey length, %d", len(peerEphPub))
+	}
+
+	shared, err := curve25519.X25519(ephPriv[:], peerEphPub)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to compute shared secret: %w", err)
+	}
+
+	smaller, larger := ephPub[:], peerEphPub
+	amSmaller := bytes.Compare(ephPub[:], peerEphPub) < 0
+	if !amSmaller {
+		smaller, larger = peerEphPub, ephPub[:]
+	}
+
+	keyOfSmaller, keyOfLarger, err := deriveDirectionKeys(shared, smaller, larger)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to derive direction keys: %w", err)
+	}
+
+	sess := &Session{rwc: rwc}
+	if amSmaller {
+		sess.sendKey, sess.recvKey = keyOfSmaller, keyOfLarger
+		sess.sendNonce, sess.recvNonce = 0, 1
+	} else {
+		sess.sendKey, sess.recvKey = keyOfLarger, keyOfSmaller
+		sess.sendNonce, sess.recvNonce = 1, 0
+	}
+
+	transcript := sha256.Sum256(append(append([]byte{}, smaller...), larger...))
+
+	sig, err := local.Sign(transcript[:])
+	if err != nil {
+		return nil, xerrors.Errorf("failed to sign handshake transcript: %w", err)
+	}
+
+	if err := sess.sendAuth(local.Publickey(), sig); err != nil {
+		return nil, xerrors.Errorf("failed to send handshake auth frame: %w", err)
+	}
+
+	peerPub, peerSig, err := sess.recvAuth()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to receive handshake auth frame: %w", err)
+	}
+
+	if !peerPub.Equal(remote) {
+		return nil, xerrors.Errorf("remote publickey does not match pinned publickey, %q != %q", peerPub, remote)
+	}
+
+	if err := peerPub.Verify(transcript[:], peerSig); err != nil {
+		return nil, xerrors.Errorf("invalid handshake signature from remote: %w", err)
+	}
+
+	return sess, nil
+}
+
+func newEphemeralKeypair() (pub, priv [32]byte, _ error) {
+	if _, err := io.ReadFull(rand.Reader, priv[:]); err != nil {
+		return pub, priv, err
+	}
+
+	p, err := curve25519.X25519(priv[:], curve25519.Basepoint)
+	if err != nil {
+		return pub, priv, err
+	}
+	copy(pub[:], p)
+
+	return pub, priv, nil
+}
+
+// deriveDirectionKeys derives the two per-direction keys from the shared
+// secret, one for the side whose ephemeral pubkey sorts smaller and one for
+// the other side. Both peers compute this identically since smaller/larger
+// are canonically ordered before being handed in.
+func deriveDirectionKeys(shared, smaller, larger []byte) (ofSmaller, ofLarger [32]byte, _ error) {
+	info := append([]byte(hkdfInfoLabel), append(append([]byte{}, smaller...), larger...)...)
+
+	r := hkdf.New(sha256.New, shared, nil, info)
+	if _, err := io.ReadFull(r, ofSmaller[:]); err != nil {
+		return ofSmaller, ofLarger, err
+	}
+	if _, err := io.ReadFull(r, ofLarger[:]); err != nil {
+		return ofSmaller, ofLarger, err
+	}
+
+	return ofSmaller, ofLarger, nil
+}
+
+func (sess *Session) sendAuth(pub key.Publickey, sig key.Signature) error {
+	pubBytes := []byte(pub.String())
+	payload := make([]byte, 4+len(pubBytes)+len(sig))
+	binary.BigEndian.PutUint32(payload, uint32(len(pubBytes)))
+	copy(payload[4:], pubBytes)
+	copy(payload[4+len(pubBytes):], sig)
+
+	return sess.Send(payload)
+}
+
+func (sess *Session) recvAuth() (key.Publickey, key.Signature, error) {
+	payload, err := sess.Receive()
+	if err != nil {
+		return nil, nil, err
+	} else if len(payload) < 4 {
+		return nil, nil, xerrors.Errorf("auth frame too short")
+	}
+
+	n := binary.BigEndian.Uint32(payload)
+	if uint32(len(payload)) < 4+n {
+		return nil, nil, xerrors.Errorf("auth frame truncated")
+	}
+
+	pub, err := key.DecodePublickeyFromString(string(payload[4 : 4+n]))
+	if err != nil {
+		return nil, nil, xerrors.Errorf("invalid publickey in auth frame: %w", err)
+	}
+
+	return pub, key.Signature(payload[4+n:]), nil
+}
+
+// Send encrypts and frames a single message. Each call consumes the next
+// send nonce; nonces increment by 2 so the two directions, even sharing a
+// wire, never reuse the same (key, nonce) pair.
+func (sess *Session) Send(b []byte) error {
+	var nonce [24]byte
+	binary.BigEndian.PutUint64(nonce[16:], sess.sendNonce)
+	sess.sendNonce += 2
+
+	box := secretbox.Seal(nil, b, &nonce, &sess.sendKey)
+
+	return writeFrame(sess.rwc, box)
+}
+
+// Receive reads and decrypts a single message sent via Send.
+func (sess *Session) Receive() ([]byte, error) {
+	box, err := readFrame(sess.rwc)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [24]byte
+	binary.BigEndian.PutUint64(nonce[16:], sess.recvNonce)
+	sess.recvNonce += 2
+
+	b, ok := secretbox.Open(nil, box, &nonce, &sess.recvKey)
+	if !ok {
+		return nil, xerrors.Errorf("failed to decrypt frame, authentication failed")
+	}
+
+	return b, nil
+}
+
+// Close closes the underlying connection.
+func (sess *Session) Close() error {
+	return sess.rwc.Close()
+}
+
+func writeFrame(w io.Writer, b []byte) error {
+	if len(b) > maxFrameSize {
+		return xerrors.Errorf("frame too large, %d", len(b))
+	}
+
+	var head [4]byte
+	binary.BigEndian.PutUint32(head[:], uint32(len(b)))
+
+	if _, err := w.Write(head[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+
+	return err
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var head [4]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(head[:])
+	if n > maxFrameSize {
+		return nil, xerrors.Errorf("frame too large, %d", n)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}